@@ -0,0 +1,11 @@
+data "fram_amster_export" "alpha" {
+  realm = "/alpha"
+}
+
+# Write the realm out as an Amster export directory.
+resource "local_file" "amster" {
+  for_each = data.fram_amster_export.alpha.files
+
+  filename = "${path.module}/amster/${data.fram_amster_export.alpha.directory}/${each.key}"
+  content  = each.value
+}
@@ -0,0 +1,20 @@
+data "fram_identities" "inactive_users" {
+  realm        = "/alpha"
+  query_filter = "inetUserStatus eq \"Inactive\""
+  fields       = ["username", "mail"]
+  limit        = 500
+}
+
+data "fram_identities" "admins" {
+  realm        = "/alpha"
+  type         = "groups"
+  query_filter = "username eq \"admins\""
+}
+
+output "inactive_usernames" {
+  value = data.fram_identities.inactive_users.identities[*].username
+}
+
+output "admin_members" {
+  value = data.fram_identities.admins.identities[0].attributes["uniquemember"]
+}
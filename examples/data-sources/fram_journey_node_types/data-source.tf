@@ -0,0 +1,12 @@
+# Fails the plan if the custom node JAR has not been deployed.
+data "fram_journey_node_types" "required" {
+  realm = "/alpha"
+  types = ["UsernameCollectorNode", "ExampleCustomNode"]
+}
+
+output "username_collector_outcomes" {
+  value = [
+    for t in data.fram_journey_node_types.required.node_types : t.outcomes[*].id
+    if t.id == "UsernameCollectorNode"
+  ]
+}
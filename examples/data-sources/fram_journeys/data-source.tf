@@ -0,0 +1,8 @@
+data "fram_journeys" "disabled" {
+  realm        = "/alpha"
+  query_filter = "enabled eq false"
+}
+
+output "disabled_journeys" {
+  value = data.fram_journeys.disabled.journeys[*].id
+}
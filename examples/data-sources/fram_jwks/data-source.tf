@@ -0,0 +1,10 @@
+data "fram_jwks" "alpha" {
+  realm = "/alpha"
+}
+
+locals {
+  signing_keys = {
+    for key in data.fram_jwks.alpha.keys : key.kid => key.public_key_pem
+    if key.use == "sig"
+  }
+}
@@ -0,0 +1,11 @@
+data "fram_oauth2_clients" "alpha" {
+  realm = "/alpha"
+}
+
+# Clients that still allow the implicit grant.
+output "implicit_clients" {
+  value = [
+    for client in data.fram_oauth2_clients.alpha.clients : client.id
+    if contains(client.grant_types, "implicit")
+  ]
+}
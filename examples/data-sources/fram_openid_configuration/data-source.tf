@@ -0,0 +1,11 @@
+data "fram_openid_configuration" "alpha" {
+  realm = "/alpha"
+}
+
+output "issuer" {
+  value = data.fram_openid_configuration.alpha.issuer
+}
+
+output "token_endpoint" {
+  value = data.fram_openid_configuration.alpha.token_endpoint
+}
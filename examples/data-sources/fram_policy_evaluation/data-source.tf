@@ -0,0 +1,22 @@
+data "fram_policy_evaluation" "orders_api" {
+  realm       = "/alpha"
+  application = "iPlanetAMWebAgentService"
+  resources   = ["https://app.example.com/api/orders"]
+
+  subject = {
+    claims = {
+      sub = "demo"
+    }
+  }
+
+  environment = {
+    IP = ["10.0.0.1"]
+  }
+}
+
+check "demo_can_read_orders" {
+  assert {
+    condition     = lookup(data.fram_policy_evaluation.orders_api.decisions[0].actions, "GET", false)
+    error_message = "demo is no longer allowed to GET the orders API."
+  }
+}
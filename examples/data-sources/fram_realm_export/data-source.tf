@@ -0,0 +1,12 @@
+data "fram_realm_export" "alpha" {
+  realm = "/alpha"
+}
+
+resource "local_file" "alpha_backup" {
+  filename = "${path.module}/alpha-${substr(data.fram_realm_export.alpha.sha256, 0, 12)}.json"
+  content  = data.fram_realm_export.alpha.json
+}
+
+output "alpha_journeys_hash" {
+  value = data.fram_realm_export.alpha.section_hashes["journeys"]
+}
@@ -0,0 +1,13 @@
+data "fram_saml2_metadata" "idp" {
+  realm     = "/alpha"
+  entity_id = "https://am.example.com/openam"
+}
+
+resource "local_file" "idp_metadata" {
+  filename = "${path.module}/idp-metadata.xml"
+  content  = data.fram_saml2_metadata.idp.xml
+}
+
+output "signing_certificate_expiry" {
+  value = [for c in data.fram_saml2_metadata.idp.certificates : c.expiry if c.use != "encryption"]
+}
@@ -0,0 +1,9 @@
+data "fram_scripts" "groovy" {
+  realm        = "/alpha"
+  query_filter = "language eq \"GROOVY\""
+  sort_keys    = ["name"]
+}
+
+output "groovy_scripts" {
+  value = data.fram_scripts.groovy.scripts[*].name
+}
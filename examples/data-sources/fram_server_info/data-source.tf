@@ -0,0 +1,11 @@
+data "fram_server_info" "alpha" {
+  realm = "/alpha"
+}
+
+output "am_version" {
+  value = data.fram_server_info.alpha.version
+}
+
+output "cookie_name" {
+  value = data.fram_server_info.alpha.cookie_name
+}
@@ -0,0 +1,3 @@
+data "fram_user" "example" {
+  query_filter = "userName eq \"demo\""
+}
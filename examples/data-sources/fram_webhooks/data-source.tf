@@ -0,0 +1,5 @@
+data "fram_webhooks" "all" {}
+
+output "webhook_names" {
+  value = data.fram_webhooks.all.webhooks[*].name
+}
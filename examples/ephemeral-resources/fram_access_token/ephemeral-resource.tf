@@ -0,0 +1,27 @@
+ephemeral "fram_access_token" "idm" {
+  realm         = "/alpha"
+  client_id     = "terraform-idm"
+  client_secret = var.idm_client_secret
+  scopes        = ["fr:idm:*"]
+}
+
+provider "restapi" {
+  uri = "https://idm.example.com/openidm"
+  headers = {
+    Authorization = "Bearer ${ephemeral.fram_access_token.idm.access_token}"
+  }
+}
+
+# JWT bearer grant with an assertion signed by a key registered with a
+# trusted JWT issuer.
+ephemeral "fram_access_token" "gateway" {
+  realm     = "/alpha"
+  client_id = "gateway-automation"
+
+  jwt_bearer = {
+    private_key_pem = var.automation_private_key_pem
+    key_id          = "automation-2024"
+    issuer          = "https://ci.example.com"
+    subject         = "pipeline"
+  }
+}
@@ -0,0 +1,21 @@
+ephemeral "fram_journey_session" "smoke" {
+  realm    = "/alpha"
+  journey  = "Login"
+  username = "smoke-test"
+  password = var.smoke_test_password
+
+  choices = {
+    "Select an MFA method" = "OTP"
+  }
+  totp_secret = var.smoke_test_totp_secret
+}
+
+data "http" "profile" {
+  url = "https://am.example.com/am/json/realms/root/realms/alpha/users?_action=idFromSession"
+
+  method = "POST"
+  request_headers = {
+    Cookie               = "iPlanetDirectoryPro=${ephemeral.fram_journey_session.smoke.token_id}"
+    "Accept-API-Version" = "resource=3.0"
+  }
+}
@@ -0,0 +1,18 @@
+locals {
+  login = provider::fram::journey_from_export(file("${path.module}/journeys/Login.journey.json"))
+}
+
+output "login_node_types" {
+  value = distinct(local.login.journey.nodes[*].node_type)
+}
+
+output "login_inner_journeys" {
+  value = local.login.inner_journeys[*].id
+}
+
+resource "local_file" "login_scripts" {
+  for_each = { for s in local.login.scripts : s.name => s }
+
+  filename = "${path.module}/scripts/${each.key}.js"
+  content  = each.value.source
+}
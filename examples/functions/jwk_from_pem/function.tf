@@ -0,0 +1,13 @@
+resource "tls_private_key" "client" {
+  algorithm   = "ECDSA"
+  ecdsa_curve = "P256"
+}
+
+locals {
+  # The kid defaults to the RFC 7638 thumbprint of the key.
+  client_jwk = provider::fram::jwk_from_pem(tls_private_key.client.public_key_pem, "", "sig")
+}
+
+output "client_kid" {
+  value = jsondecode(local.client_jwk).kid
+}
@@ -0,0 +1,11 @@
+locals {
+  # Publish the current and the next signing key during a rotation.
+  client_jwks = provider::fram::jwks([
+    provider::fram::jwk_from_pem(tls_self_signed_cert.current.cert_pem, "", "sig"),
+    provider::fram::jwk_from_pem(tls_self_signed_cert.next.cert_pem, "", "sig"),
+  ])
+}
+
+output "client_jwks" {
+  value = local.client_jwks
+}
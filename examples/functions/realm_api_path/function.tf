@@ -0,0 +1,4 @@
+data "http" "alpha_serverinfo" {
+  # https://am.example.com/openam/json/realms/root/realms/alpha/serverinfo/*
+  url = "https://am.example.com/openam/json${provider::fram::realm_api_path("alpha")}/serverinfo/*"
+}
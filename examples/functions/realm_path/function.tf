@@ -0,0 +1,8 @@
+locals {
+  # "/alpha", whichever form the variable uses.
+  realm = provider::fram::realm_path(var.realm)
+}
+
+output "realm" {
+  value = local.realm
+}
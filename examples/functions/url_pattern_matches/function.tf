@@ -0,0 +1,9 @@
+check "login_redirect_allowed" {
+  assert {
+    condition = anytrue([
+      for pattern in fram_validation.alpha.valid_goto_destinations :
+      provider::fram::url_pattern_matches(pattern, "https://app.example.com/callback?state=xyz")
+    ])
+    error_message = "The app callback is no longer an allowed goto destination."
+  }
+}
@@ -0,0 +1,8 @@
+variable "goto_destinations" {
+  type = set(string)
+
+  validation {
+    condition     = alltrue([for p in var.goto_destinations : provider::fram::url_pattern_valid(p)])
+    error_message = "Every goto destination must be a valid AM URL pattern."
+  }
+}
@@ -0,0 +1,10 @@
+locals {
+  amster_realm = "${path.module}/amster/realms/root-alpha"
+}
+
+resource "fram_amster_import" "alpha" {
+  realm = "/alpha"
+  files = {
+    for f in fileset(local.amster_realm, "**/*.json") : f => file("${local.amster_realm}/${f}")
+  }
+}
@@ -0,0 +1,17 @@
+resource "fram_auth_chain" "example" {
+  name = "corporateService"
+
+  modules = [
+    {
+      module   = fram_auth_module.example.name
+      criteria = "REQUISITE"
+    },
+    {
+      module   = "HOTP"
+      criteria = "REQUIRED"
+      options = {
+        "iplanet-am-auth-shared-state-enabled" = "true"
+      }
+    },
+  ]
+}
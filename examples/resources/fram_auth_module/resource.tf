@@ -0,0 +1,11 @@
+resource "fram_auth_module" "example" {
+  type                 = "ldap"
+  name                 = "Corporate"
+  authentication_level = 0
+
+  settings = {
+    primaryLdapServer    = jsonencode(["ldap.example.com:389"])
+    userBaseDn           = jsonencode(["ou=people,dc=example,dc=com"])
+    userSearchAttributes = jsonencode(["uid"])
+  }
+}
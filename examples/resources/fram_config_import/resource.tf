@@ -0,0 +1,11 @@
+# Apply a full realm export taken with `frodo config export --all`.
+resource "fram_config_import" "alpha" {
+  realm   = "/alpha"
+  content = file("${path.module}/exports/alpha.json")
+}
+
+# Or one journey, with its nodes and scripts, at a time.
+resource "fram_config_import" "login" {
+  realm   = "/alpha"
+  content = file("${path.module}/exports/Login.journey.json")
+}
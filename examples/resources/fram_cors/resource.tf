@@ -0,0 +1,8 @@
+resource "fram_cors" "example" {
+  name              = "frontend"
+  accepted_origins  = ["https://app.example.com", "https://admin.example.com:8443"]
+  accepted_methods  = ["GET", "POST", "PUT", "DELETE"]
+  accepted_headers  = ["Authorization", "Content-Type", "Accept-API-Version"]
+  allow_credentials = true
+  max_age           = 600
+}
@@ -0,0 +1,5 @@
+resource "fram_group" "example" {
+  name       = "realm-admins"
+  members    = [fram_user.example.id]
+  privileges = ["RealmAdmin"]
+}
@@ -0,0 +1,12 @@
+resource "fram_server" "example" {
+  url  = "https://am1.example.com:8443/openam"
+  site = fram_site.example.name
+
+  general = {
+    debug_level = "error"
+  }
+
+  advanced = {
+    "com.iplanet.am.lbcookie.value" = "01"
+  }
+}
@@ -0,0 +1,13 @@
+resource "fram_server_defaults" "example" {
+  security = {
+    cookie_secure = true
+  }
+
+  cts = {
+    max_connections = 100
+  }
+
+  advanced = {
+    "org.forgerock.openam.idm.attribute.names.lower.case" = "true"
+  }
+}
@@ -0,0 +1,6 @@
+resource "fram_session" "example" {
+  max_session_time   = 480
+  max_idle_time      = 30
+  quota_limit        = 3
+  property_whitelist = ["AMCtxId", "LoginURL"]
+}
@@ -0,0 +1,5 @@
+resource "fram_site" "example" {
+  name           = "site1"
+  url            = "https://am.example.com:443/openam"
+  secondary_urls = ["https://am-internal.example.com:443/openam"]
+}
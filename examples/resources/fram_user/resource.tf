@@ -0,0 +1,11 @@
+resource "fram_user" "example" {
+  username = "breakglass"
+  password = var.breakglass_password
+  email    = "breakglass@example.com"
+  status   = "Active"
+
+  attributes = {
+    givenName = "Break"
+    sn        = "Glass"
+  }
+}
@@ -0,0 +1,6 @@
+resource "fram_validation" "example" {
+  valid_goto_destinations = [
+    "https://app.example.com:443/*",
+    "https://app.example.com:443/*?*",
+  ]
+}
@@ -0,0 +1,10 @@
+resource "fram_webhook" "logout" {
+  name = "logoutWebhook"
+  url  = "https://audit.example.com/logout"
+  body = jsonencode({ user = "$${session.UserId}" })
+
+  headers = {
+    "Content-Type"  = "application/json"
+    "Authorization" = "Bearer ${var.audit_token}"
+  }
+}
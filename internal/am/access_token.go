@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// JWTBearerGrantType is the grant_type of the RFC 7523 JWT bearer grant.
+const JWTBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+// AccessToken is a successful response of the token endpoint.
+type AccessToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// RequestAccessToken posts form to the token endpoint of realm. The client
+// authenticates with HTTP Basic when clientSecret is set, and only sends its
+// ID otherwise, as public clients and signed assertions do.
+func (c *Client) RequestAccessToken(ctx context.Context, realm, clientID, clientSecret string, form url.Values) (*AccessToken, error) {
+	if clientSecret == "" {
+		form.Set("client_id", clientID)
+	}
+
+	u, err := c.OAuth2URL(realm, "/access_token")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	body, status, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status > 299 {
+		return nil, &Error{StatusCode: status, Body: body}
+	}
+
+	var token AccessToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
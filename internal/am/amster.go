@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// AmsterFile is one entity file of an Amster export (`export-config`).
+type AmsterFile struct {
+	Metadata AmsterMetadata         `json:"metadata"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+// AmsterMetadata identifies the entity of an AmsterFile.
+type AmsterMetadata struct {
+	Realm         string            `json:"realm"`
+	AmsterVersion string            `json:"amsterVersion,omitempty"`
+	EntityType    string            `json:"entityType"`
+	EntityID      string            `json:"entityId"`
+	PathParams    map[string]string `json:"pathParams"`
+}
+
+// amsterEntityTypes maps the Amster entity types that are not nodes or
+// services to entity kinds and the RealmExport sections they come from.
+var amsterEntityTypes = []struct {
+	EntityType string
+	Kind       string
+	Section    string
+}{
+	{"Scripts", "script", "scripts"},
+	{"ResourceTypes", "resourceType", "resourceTypes"},
+	{"Applications", "policySet", "policySets"},
+	{"Policies", "policy", "policies"},
+	{"OAuth2Clients", "oauth2Client", "oauth2Clients"},
+	{"AuthTree", "journey", "journeys"},
+}
+
+// AmsterSections are the RealmExport sections AmsterFiles renders.
+var AmsterSections = []string{"services", "journeys", "nodes", "scripts", "oauth2Clients", "policySets", "policies", "resourceTypes"}
+
+// AmsterRealmDirectory returns the directory Amster keeps the entities of
+// realm in, relative to the export path, such as "realms/root-alpha".
+func AmsterRealmDirectory(realm string) (string, error) {
+	parts, err := ParseRealm(realm)
+	if err != nil {
+		return "", err
+	}
+	return "realms/" + strings.Join(append([]string{"root"}, parts...), "-"), nil
+}
+
+// AmsterFiles renders export as Amster entity files keyed by their path
+// relative to AmsterRealmDirectory, "<entity type>/<entity id>.json".
+// Services are named after their type, as Amster names them, and nodes after
+// their node type. Agents are not rendered.
+func AmsterFiles(realm, amsterVersion string, export RealmExport) (map[string]AmsterFile, error) {
+	files := map[string]AmsterFile{}
+	add := func(entityType, id string, obj interface{}) error {
+		data, ok := obj.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s %q is not an object", entityType, id)
+		}
+		files[path.Join(entityType, url.PathEscape(id)+".json")] = AmsterFile{
+			Metadata: AmsterMetadata{
+				Realm:         realm,
+				AmsterVersion: amsterVersion,
+				EntityType:    entityType,
+				EntityID:      id,
+				PathParams:    map[string]string{},
+			},
+			Data: data,
+		}
+		return nil
+	}
+
+	for _, t := range amsterEntityTypes {
+		for id, obj := range export[t.Section] {
+			if err := add(t.EntityType, id, obj); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for id, obj := range export["nodes"] {
+		data, _ := obj.(map[string]interface{})
+		nodeType := typeID(data)
+		if nodeType == "" {
+			return nil, fmt.Errorf("node %q has no _type", id)
+		}
+		if err := add(nodeType, id, obj); err != nil {
+			return nil, err
+		}
+	}
+	for typ, obj := range export["services"] {
+		data, _ := obj.(map[string]interface{})
+		if err := add(amsterServiceType(data, typ), typ, obj); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// amsterServiceType derives the Amster entity type of a service from the
+// display name of its type, "OAuth2 Provider" becoming "OAuth2Provider".
+func amsterServiceType(data map[string]interface{}, typ string) string {
+	t, _ := data["_type"].(map[string]interface{})
+	name, _ := t["name"].(string)
+	if name = strings.Join(strings.Fields(name), ""); name == "" {
+		return typ
+	}
+	return name
+}
+
+// ParseAmsterFiles reads Amster entity files, keyed by file name, into the
+// entities of realm. Files of other realms and entity types the import
+// cannot write are returned as skipped. Nodes are recognised by the
+// _outcomes or _type their data carries, services by a _type without
+// _outcomes.
+func ParseAmsterFiles(files map[string][]byte, realm string) ([]ConfigEntity, []string, error) {
+	want, err := CanonicalRealm(realm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entities := map[string]ConfigEntity{}
+	var skipped []string
+	for _, name := range names {
+		var f AmsterFile
+		if err := json.Unmarshal(files[name], &f); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse Amster file %q: %w", name, err)
+		}
+		if f.Metadata.EntityType == "" || f.Data == nil {
+			return nil, nil, fmt.Errorf("Amster file %q has no metadata.entityType or data", name)
+		}
+		if fileRealm, err := CanonicalRealm(f.Metadata.Realm); err != nil || f.Metadata.Realm == "" || fileRealm != want {
+			skipped = append(skipped, name)
+			continue
+		}
+		Normalize(f.Data)
+
+		id := f.Metadata.EntityID
+		if dataID, ok := f.Data["_id"].(string); ok && dataID != "" {
+			id = dataID
+		}
+		e := ConfigEntity{ID: id, Object: f.Data}
+		for _, t := range amsterEntityTypes {
+			if t.EntityType == f.Metadata.EntityType {
+				e.Kind = t.Kind
+			}
+		}
+		_, isNode := f.Data["_outcomes"]
+		switch {
+		case e.Kind == "script":
+			source, err := frodoScriptSource(mustMarshal(f.Data["script"]))
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to parse Amster file %q: %w", name, err)
+			}
+			f.Data["script"] = base64.StdEncoding.EncodeToString([]byte(source))
+		case e.Kind != "":
+		case isNode || strings.HasSuffix(f.Metadata.EntityType, "Node"):
+			nodeType := typeID(f.Data)
+			if nodeType == "" {
+				nodeType = f.Metadata.EntityType
+			}
+			e.Kind, e.ID = "node", nodeType+"/"+id
+		case typeID(f.Data) != "":
+			e.Kind, e.ID = "service", typeID(f.Data)
+		default:
+			skipped = append(skipped, name)
+			continue
+		}
+		entities[e.Key()] = e
+	}
+
+	if len(entities) == 0 {
+		return nil, skipped, fmt.Errorf("no Amster file of realm %s to import", want)
+	}
+	markInnerNodes(entities)
+	return sortedConfigEntities(entities), skipped, nil
+}
+
+// markInnerNodes flags the nodes placed inside a Page node, which must be
+// written before it.
+func markInnerNodes(entities map[string]ConfigEntity) {
+	inner := map[string]bool{}
+	for _, e := range entities {
+		if e.Kind != "node" || typeID(e.Object) != "PageNode" {
+			continue
+		}
+		children, _ := e.Object["nodes"].([]interface{})
+		for _, c := range children {
+			child, _ := c.(map[string]interface{})
+			if id, ok := child["_id"].(string); ok {
+				inner[id] = true
+			}
+		}
+	}
+	for _, e := range entities {
+		if _, id, ok := strings.Cut(e.ID, "/"); ok && e.Kind == "node" && inner[id] {
+			e.Object["_inner"] = true
+		}
+	}
+}
+
+// Encode renders f the way Amster writes it: indented with two spaces and
+// without HTML escaping.
+func (f AmsterFile) Encode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// AuthModuleTypes are the legacy authentication module types the provider
+// knows how to manage, keyed by their REST type name.
+var AuthModuleTypes = []string{"ldap", "datastore", "hotp", "scripted", "oauth2", "openidconnect"}
+
+// AuthModule is a legacy (AM 6.5 style) authentication module instance. The
+// attributes differ per module type, so they are kept as raw JSON.
+type AuthModule struct {
+	ID         string
+	Type       string
+	Attributes map[string]json.RawMessage
+}
+
+// MarshalJSON flattens the module attributes next to its _id.
+func (m AuthModule) MarshalJSON() ([]byte, error) {
+	out := map[string]json.RawMessage{}
+	for k, v := range m.Attributes {
+		out[k] = v
+	}
+	id, err := json.Marshal(m.ID)
+	if err != nil {
+		return nil, err
+	}
+	out["_id"] = id
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON splits the CREST metadata from the module attributes.
+func (m *AuthModule) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Attributes = map[string]json.RawMessage{}
+	for k, v := range raw {
+		switch k {
+		case "_id":
+			if err := json.Unmarshal(v, &m.ID); err != nil {
+				return err
+			}
+		case "_type":
+			var t struct {
+				ID string `json:"_id"`
+			}
+			if err := json.Unmarshal(v, &t); err != nil {
+				return err
+			}
+			m.Type = t.ID
+		case "_rev":
+		default:
+			m.Attributes[k] = v
+		}
+	}
+	return nil
+}
+
+// AuthChain is a legacy authentication chain.
+type AuthChain struct {
+	ID                     string           `json:"_id"`
+	AuthChainConfiguration []AuthChainEntry `json:"authChainConfiguration"`
+}
+
+// AuthChainEntry is one module in an authentication chain.
+type AuthChainEntry struct {
+	Module   string            `json:"module"`
+	Criteria string            `json:"criteria"`
+	Options  map[string]string `json:"options"`
+}
+
+func authModulePath(moduleType, name string) string {
+	return "/realm-config/authentication/modules/" + url.PathEscape(moduleType) + "/" + url.PathEscape(name)
+}
+
+func authChainPath(name string) string {
+	return "/realm-config/authentication/chains/" + url.PathEscape(name)
+}
+
+// GetAuthModule reads an authentication module of the client's realm.
+func (c *Client) GetAuthModule(ctx context.Context, moduleType, name string) (*AuthModule, error) {
+	var m AuthModule
+	if err := c.Get(ctx, c.RealmPath(authModulePath(moduleType, name)), ServiceAPIVersion, &m); err != nil {
+		return nil, err
+	}
+	if m.Type == "" {
+		m.Type = moduleType
+	}
+	return &m, nil
+}
+
+// CreateAuthModule creates an authentication module in the client's realm.
+func (c *Client) CreateAuthModule(ctx context.Context, m AuthModule) (*AuthModule, error) {
+	var result AuthModule
+	path := c.RealmPath("/realm-config/authentication/modules/" + url.PathEscape(m.Type) + "?_action=create")
+	if err := c.Post(ctx, path, ServiceAPIVersion, m, &result); err != nil {
+		return nil, err
+	}
+	if result.Type == "" {
+		result.Type = m.Type
+	}
+	return &result, nil
+}
+
+// UpdateAuthModule replaces the attributes of an authentication module.
+func (c *Client) UpdateAuthModule(ctx context.Context, m AuthModule) (*AuthModule, error) {
+	var result AuthModule
+	if err := c.Put(ctx, c.RealmPath(authModulePath(m.Type, m.ID)), ServiceAPIVersion, m, &result); err != nil {
+		return nil, err
+	}
+	if result.Type == "" {
+		result.Type = m.Type
+	}
+	return &result, nil
+}
+
+// DeleteAuthModule deletes an authentication module of the client's realm.
+func (c *Client) DeleteAuthModule(ctx context.Context, moduleType, name string) error {
+	return c.Delete(ctx, c.RealmPath(authModulePath(moduleType, name)), ServiceAPIVersion, nil)
+}
+
+// GetAuthChain reads an authentication chain of the client's realm.
+func (c *Client) GetAuthChain(ctx context.Context, name string) (*AuthChain, error) {
+	var chain AuthChain
+	if err := c.Get(ctx, c.RealmPath(authChainPath(name)), ServiceAPIVersion, &chain); err != nil {
+		return nil, err
+	}
+	return &chain, nil
+}
+
+// CreateAuthChain creates an authentication chain in the client's realm.
+func (c *Client) CreateAuthChain(ctx context.Context, chain AuthChain) (*AuthChain, error) {
+	var result AuthChain
+	if err := c.Post(ctx, c.RealmPath("/realm-config/authentication/chains?_action=create"), ServiceAPIVersion, chain, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAuthChain replaces the modules of an authentication chain.
+func (c *Client) UpdateAuthChain(ctx context.Context, chain AuthChain) (*AuthChain, error) {
+	var result AuthChain
+	if err := c.Put(ctx, c.RealmPath(authChainPath(chain.ID)), ServiceAPIVersion, chain, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAuthChain deletes an authentication chain of the client's realm.
+func (c *Client) DeleteAuthChain(ctx context.Context, name string) error {
+	return c.Delete(ctx, c.RealmPath(authChainPath(name)), ServiceAPIVersion, nil)
+}
+
+// ListAuthModules returns every authentication module of the client's realm.
+func (c *Client) ListAuthModules(ctx context.Context) ([]AuthModule, error) {
+	results, err := c.Query(ctx, c.RealmPath("/realm-config/authentication/modules"), ServiceAPIVersion, QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+	modules := make([]AuthModule, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &modules[i]); err != nil {
+			return nil, err
+		}
+	}
+	return modules, nil
+}
+
+// ListAuthChains returns every authentication chain of the client's realm.
+func (c *Client) ListAuthChains(ctx context.Context) ([]AuthChain, error) {
+	results, err := c.Query(ctx, c.RealmPath("/realm-config/authentication/chains"), ServiceAPIVersion, QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+	chains := make([]AuthChain, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &chains[i]); err != nil {
+			return nil, err
+		}
+	}
+	return chains, nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SessionAPIVersion is the Accept-API-Version used for /json/sessions.
+const SessionAPIVersion = "protocol=1.0,resource=4.0"
+
+// maxJourneySteps bounds the callback exchange of AuthenticateJourney so a
+// journey that keeps asking the same question cannot loop forever.
+const maxJourneySteps = 20
+
+// Callback is one callback of an /json/authenticate step.
+type Callback struct {
+	Type   string          `json:"type"`
+	Output []CallbackValue `json:"output"`
+	Input  []CallbackValue `json:"input"`
+}
+
+// CallbackValue is a name/value pair of a callback's output or input.
+type CallbackValue struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// OutputString returns the string output called name, or "".
+func (cb *Callback) OutputString(name string) string {
+	for _, o := range cb.Output {
+		if o.Name == name {
+			var s string
+			_ = json.Unmarshal(o.Value, &s)
+			return s
+		}
+	}
+	return ""
+}
+
+// Prompt returns the prompt of the callback.
+func (cb *Callback) Prompt() string {
+	return cb.OutputString("prompt")
+}
+
+// SetInput sets the first input of the callback to v.
+func (cb *Callback) SetInput(v interface{}) error {
+	if len(cb.Input) == 0 {
+		return fmt.Errorf("%s has no input", cb.Type)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	cb.Input[0].Value = b
+	return nil
+}
+
+// authStep is a response of /json/authenticate: either the callbacks of the
+// next step, identified by AuthID, or the final session.
+type authStep struct {
+	AuthID     string     `json:"authId,omitempty"`
+	Callbacks  []Callback `json:"callbacks,omitempty"`
+	TokenID    string     `json:"tokenId,omitempty"`
+	SuccessURL string     `json:"successUrl,omitempty"`
+	Realm      string     `json:"realm,omitempty"`
+}
+
+// CallbackAnswers are the values a journey is answered with.
+type CallbackAnswers struct {
+	Username string
+	Password string
+	// Choices maps the prompt of a ChoiceCallback to the text of the choice
+	// to pick; unlisted prompts take the default choice.
+	Choices map[string]string
+	// OneTimePassword returns the code for a one-time password prompt.
+	OneTimePassword func() (string, error)
+}
+
+// IsOneTimePasswordPrompt reports whether a NameCallback or PasswordCallback
+// prompt asks for a one-time password, as the OTP Collector Decision and
+// OATH Token Verifier nodes do.
+func IsOneTimePasswordPrompt(prompt string) bool {
+	p := strings.ToLower(prompt)
+	for _, s := range []string{"one time password", "one-time password", "otp", "verification code"} {
+		if strings.Contains(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Answer fills in the input of cb.
+func (a CallbackAnswers) Answer(cb *Callback) error {
+	switch cb.Type {
+	case "TextOutputCallback", "MetadataCallback":
+		return nil
+	case "NameCallback", "PasswordCallback", "OneTimePasswordCallback":
+		if cb.Type == "OneTimePasswordCallback" || IsOneTimePasswordPrompt(cb.Prompt()) {
+			if a.OneTimePassword == nil {
+				return fmt.Errorf("journey asks for a one-time password (%q) but none is configured", cb.Prompt())
+			}
+			code, err := a.OneTimePassword()
+			if err != nil {
+				return err
+			}
+			return cb.SetInput(code)
+		}
+		if cb.Type == "NameCallback" {
+			return cb.SetInput(a.Username)
+		}
+		return cb.SetInput(a.Password)
+	case "ChoiceCallback":
+		return answerChoice(cb, a.Choices[cb.Prompt()])
+	case "ConfirmationCallback":
+		var def int
+		for _, o := range cb.Output {
+			if o.Name == "defaultOption" {
+				_ = json.Unmarshal(o.Value, &def)
+			}
+		}
+		return cb.SetInput(def)
+	}
+	return fmt.Errorf("unsupported callback %s", cb.Type)
+}
+
+func answerChoice(cb *Callback, choice string) error {
+	var choices []string
+	def := 0
+	for _, o := range cb.Output {
+		switch o.Name {
+		case "choices":
+			_ = json.Unmarshal(o.Value, &choices)
+		case "defaultChoice":
+			_ = json.Unmarshal(o.Value, &def)
+		}
+	}
+	if choice == "" {
+		return cb.SetInput(def)
+	}
+	for i, c := range choices {
+		if strings.EqualFold(c, choice) {
+			return cb.SetInput(i)
+		}
+	}
+	return fmt.Errorf("%q is not one of the choices %q of %q", choice, choices, cb.Prompt())
+}
+
+// AuthenticateJourney signs in to realm through journey, answering each
+// step's callbacks from answers, and returns the resulting session. An
+// empty journey uses the default journey of the realm.
+func (c *Client) AuthenticateJourney(ctx context.Context, realm, journey string, answers CallbackAnswers) (*AuthResponse, error) {
+	path, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	path += "/authenticate"
+	if journey != "" {
+		path += "?authIndexType=service&authIndexValue=" + url.QueryEscape(journey)
+	}
+
+	var step authStep
+	for i := 0; i < maxJourneySteps; i++ {
+		var in interface{}
+		if i > 0 {
+			for j := range step.Callbacks {
+				if err := answers.Answer(&step.Callbacks[j]); err != nil {
+					return nil, err
+				}
+			}
+			in = authStep{AuthID: step.AuthID, Callbacks: step.Callbacks}
+		}
+
+		var next authStep
+		if err := c.do(ctx, http.MethodPost, path, AuthenticateAPIVersion, nil, in, &next, false); err != nil {
+			return nil, err
+		}
+		if next.TokenID != "" {
+			return &AuthResponse{TokenID: next.TokenID, SuccessURL: next.SuccessURL, Realm: next.Realm}, nil
+		}
+		if next.AuthID == "" || len(next.Callbacks) == 0 {
+			return nil, fmt.Errorf("journey returned neither callbacks nor a session")
+		}
+		step = next
+	}
+	return nil, fmt.Errorf("journey did not complete within %d steps", maxJourneySteps)
+}
+
+// SessionInfo describes a session, as returned by getSessionInfo.
+type SessionInfo struct {
+	Username                 string            `json:"username"`
+	UniversalID              string            `json:"universalId"`
+	Realm                    string            `json:"realm"`
+	MaxIdleExpirationTime    string            `json:"maxIdleExpirationTime"`
+	MaxSessionExpirationTime string            `json:"maxSessionExpirationTime"`
+	Properties               map[string]string `json:"properties"`
+}
+
+// sessionHeader returns the header that presents token as the SSO cookie.
+func (c *Client) sessionHeader(ctx context.Context, token string) http.Header {
+	header := http.Header{}
+	header.Set("Cookie", (&http.Cookie{Name: c.CookieName(ctx), Value: token}).String())
+	return header
+}
+
+// GetSessionInfo reads the session token in realm.
+func (c *Client) GetSessionInfo(ctx context.Context, realm, token string) (*SessionInfo, error) {
+	path, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var info SessionInfo
+	if err := c.do(ctx, http.MethodPost, path+"/sessions?_action=getSessionInfo", SessionAPIVersion, c.sessionHeader(ctx, token), struct{}{}, &info, false); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Logout ends the session token in realm.
+func (c *Client) Logout(ctx context.Context, realm, token string) error {
+	path, err := RealmAPIPath(realm)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, path+"/sessions?_action=logout", SessionAPIVersion, c.sessionHeader(ctx, token), struct{}{}, nil, false)
+}
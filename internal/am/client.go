@@ -0,0 +1,434 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package am is a thin REST client for the ForgeRock Access Manager endpoints
+// that the provider manages directly rather than through fram-client-go.
+package am
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHostURL is used when the provider configuration does not set a host.
+	DefaultHostURL = "http://localhost:8080/openam"
+	// DefaultUsername is used when the provider configuration does not set a username.
+	DefaultUsername = "amadmin"
+	// DefaultPassword is used when the provider configuration does not set a password.
+	DefaultPassword = "p4ssw0rd"
+	// DefaultRealm is used when the provider configuration does not set a realm.
+	DefaultRealm = "/"
+	// DefaultCookieName is the SSO cookie name used when serverinfo cannot be read.
+	DefaultCookieName = "iPlanetDirectoryPro"
+
+	// ServiceAPIVersion is the Accept-API-Version used for service configuration.
+	ServiceAPIVersion = "protocol=1.0,resource=1.0"
+	// AuthenticateAPIVersion is the Accept-API-Version used for /json/authenticate.
+	AuthenticateAPIVersion = "protocol=1.0,resource=2.0"
+)
+
+// Client talks to the AM REST API on behalf of a single administrative user.
+type Client struct {
+	HostURL    string
+	Realm      string
+	HTTPClient *http.Client
+	Auth       AuthStruct
+
+	*session
+}
+
+// session is the state a client shares with the clients WithRealm returns.
+type session struct {
+	mu         sync.Mutex
+	token      string
+	cookieName string
+	version    *Version
+
+	// authMu makes concurrent requests that need a session wait for a single
+	// sign in, rather than each running the journey and using up a TOTP step.
+	authMu sync.Mutex
+}
+
+// AuthStruct holds the administrative credentials. When Journey is set the
+// user signs in through that journey of the root realm instead of with the
+// X-OpenAM-Username and X-OpenAM-Password headers, answering one-time
+// password prompts from TOTP.
+type AuthStruct struct {
+	Username string
+	Password string
+	Journey  string
+	TOTP     *TOTP
+}
+
+// AuthResponse is the final response of a successful /json/authenticate call.
+type AuthResponse struct {
+	TokenID    string `json:"tokenId"`
+	SuccessURL string `json:"successUrl"`
+	Realm      string `json:"realm"`
+}
+
+// Error is returned for every non-2xx response. The message format matches
+// the one used by fram-client-go so callers can treat both clients alike.
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an AM 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// NewClient returns a client for the given host, falling back to the
+// documented provider defaults for any nil or empty value. Authentication is
+// deferred until the first request that needs a session.
+func NewClient(host, username, password, realm *string) (*Client, error) {
+	c := Client{
+		HostURL:    valueOrDefault(host, DefaultHostURL),
+		Realm:      valueOrDefault(realm, DefaultRealm),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Auth: AuthStruct{
+			Username: valueOrDefault(username, DefaultUsername),
+			Password: valueOrDefault(password, DefaultPassword),
+		},
+		session: &session{},
+	}
+	c.HostURL = strings.TrimSuffix(c.HostURL, "/")
+
+	if !strings.HasPrefix(c.HostURL, "http://") && !strings.HasPrefix(c.HostURL, "https://") {
+		return nil, fmt.Errorf("host %q must start with http:// or https://", c.HostURL)
+	}
+	realmName, err := CanonicalRealm(c.Realm)
+	if err != nil {
+		return nil, err
+	}
+	c.Realm = realmName
+
+	return &c, nil
+}
+
+// WithRealm returns a client for realm that shares the host, credentials
+// and session of c, so that reading several realms signs in once.
+func (c *Client) WithRealm(realm string) (*Client, error) {
+	realmName, err := CanonicalRealm(realm)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{HostURL: c.HostURL, Realm: realmName, HTTPClient: c.HTTPClient, Auth: c.Auth, session: c.session}, nil
+}
+
+func valueOrDefault(v *string, def string) string {
+	if v == nil || *v == "" {
+		return def
+	}
+	return *v
+}
+
+// RealmPath prefixes p with the REST path of the client's realm.
+// The realm of the client is checked by NewClient.
+func (c *Client) RealmPath(p string) string {
+	parts, _ := ParseRealm(c.Realm)
+	return realmAPIPath(parts) + p
+}
+
+// RealmAPIPath converts a realm name such as "/alpha" into the path segment
+// AM expects in realm-scoped URLs, e.g. "/realms/root/realms/alpha". It
+// fails for the names ParseRealm rejects.
+func RealmAPIPath(realm string) (string, error) {
+	parts, err := ParseRealm(realm)
+	if err != nil {
+		return "", err
+	}
+	return realmAPIPath(parts), nil
+}
+
+func realmAPIPath(parts []string) string {
+	var b strings.Builder
+	b.WriteString("/realms/root")
+	for _, part := range parts {
+		b.WriteString("/realms/")
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// ParseRealm splits a realm given in any of the forms used by AM's APIs and
+// documentation into its sub-realm names, dropping the root realm: "/", "",
+// "root" and "/realms/root" all name the root realm, while "alpha", "/alpha",
+// "root/alpha" and "/realms/root/realms/alpha" all name the alpha sub-realm.
+func ParseRealm(realm string) ([]string, error) {
+	parts := strings.FieldsFunc(strings.TrimSpace(realm), func(r rune) bool { return r == '/' })
+
+	if len(parts) > 0 && parts[0] == "realms" {
+		// REST path form: alternating "realms" keys and realm names.
+		if len(parts)%2 != 0 || parts[1] != "root" {
+			return nil, fmt.Errorf("realm path %q must look like /realms/root/realms/<name>", realm)
+		}
+		var names []string
+		for i := 2; i < len(parts); i += 2 {
+			if parts[i] != "realms" {
+				return nil, fmt.Errorf("realm path %q must look like /realms/root/realms/<name>", realm)
+			}
+			names = append(names, parts[i+1])
+		}
+		return names, nil
+	}
+
+	if len(parts) > 0 && parts[0] == "root" {
+		parts = parts[1:]
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) != part {
+			return nil, fmt.Errorf("realm %q contains a name with leading or trailing spaces", realm)
+		}
+		if strings.ContainsAny(part, `?#%\`) {
+			return nil, fmt.Errorf("realm %q contains a name with one of the characters ?, #, %% or \\", realm)
+		}
+	}
+	return parts, nil
+}
+
+// CanonicalRealm returns realm in the "/alpha" form, or "/" for the root
+// realm.
+func CanonicalRealm(realm string) (string, error) {
+	parts, err := ParseRealm(realm)
+	if err != nil {
+		return "", err
+	}
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+// CookieName returns the SSO cookie name advertised by the server.
+func (c *Client) CookieName(ctx context.Context) string {
+	c.mu.Lock()
+	name := c.cookieName
+	c.mu.Unlock()
+	if name != "" {
+		return name
+	}
+
+	var info struct {
+		CookieName string `json:"cookieName"`
+	}
+	name = DefaultCookieName
+	if err := c.do(ctx, http.MethodGet, "/serverinfo/*", ServiceAPIVersion, nil, nil, &info, false); err == nil && info.CookieName != "" {
+		name = info.CookieName
+	}
+
+	c.mu.Lock()
+	c.cookieName = name
+	c.mu.Unlock()
+	return name
+}
+
+// Authenticate signs the administrative user in to the root realm and keeps
+// the resulting session token for subsequent requests. It is called again,
+// through the same journey, whenever the session expires.
+func (c *Client) Authenticate(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.authenticate(ctx)
+}
+
+func (c *Client) authenticate(ctx context.Context) error {
+	var ar AuthResponse
+	if c.Auth.Journey != "" {
+		answers := CallbackAnswers{Username: c.Auth.Username, Password: c.Auth.Password}
+		if c.Auth.TOTP != nil {
+			answers.OneTimePassword = func() (string, error) { return c.Auth.TOTP.Code(ctx) }
+		}
+		result, err := c.AuthenticateJourney(ctx, "/", c.Auth.Journey, answers)
+		if err != nil {
+			return fmt.Errorf("unable to authenticate as %q through journey %q: %w", c.Auth.Username, c.Auth.Journey, err)
+		}
+		ar = *result
+	} else {
+		header := http.Header{}
+		header.Set("X-OpenAM-Username", c.Auth.Username)
+		header.Set("X-OpenAM-Password", c.Auth.Password)
+
+		if err := c.do(ctx, http.MethodPost, "/realms/root/authenticate", AuthenticateAPIVersion, header, nil, &ar, false); err != nil {
+			return fmt.Errorf("unable to authenticate as %q: %w", c.Auth.Username, err)
+		}
+	}
+	if ar.TokenID == "" {
+		return fmt.Errorf("unable to authenticate as %q: no token returned", c.Auth.Username)
+	}
+
+	c.mu.Lock()
+	c.token = ar.TokenID
+	c.mu.Unlock()
+	return nil
+}
+
+// SignOut ends the session of the administrative user, if there is one. A
+// later request signs in again.
+func (c *Client) SignOut(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	token := c.currentToken()
+	if token == "" {
+		return nil
+	}
+	c.expireToken(token)
+	return c.Logout(ctx, "/", token)
+}
+
+func (c *Client) sessionToken(ctx context.Context) (string, error) {
+	if token := c.currentToken(); token != "" {
+		return token, nil
+	}
+
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	// Another request may have signed in while this one waited.
+	if token := c.currentToken(); token != "" {
+		return token, nil
+	}
+	if err := c.authenticate(ctx); err != nil {
+		return "", err
+	}
+	return c.currentToken(), nil
+}
+
+func (c *Client) currentToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// expireToken forgets token after AM rejected it, unless another request has
+// already replaced it with a new session.
+func (c *Client) expireToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+// Get reads the resource at path, relative to /json, into out.
+func (c *Client) Get(ctx context.Context, path, apiVersion string, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, apiVersion, nil, nil, out, true)
+}
+
+// Put writes in to the resource at path and decodes the response into out.
+func (c *Client) Put(ctx context.Context, path, apiVersion string, in, out interface{}) error {
+	return c.do(ctx, http.MethodPut, path, apiVersion, nil, in, out, true)
+}
+
+// Post sends in to path, which usually carries an _action parameter.
+func (c *Client) Post(ctx context.Context, path, apiVersion string, in, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, apiVersion, nil, in, out, true)
+}
+
+// Delete removes the resource at path.
+func (c *Client) Delete(ctx context.Context, path, apiVersion string, out interface{}) error {
+	return c.do(ctx, http.MethodDelete, path, apiVersion, nil, nil, out, true)
+}
+
+// Do is the general form of Get, Put, Post and Delete for callers that need
+// extra request headers.
+func (c *Client) Do(ctx context.Context, method, path, apiVersion string, header http.Header, in, out interface{}) error {
+	return c.do(ctx, method, path, apiVersion, header, in, out, true)
+}
+
+func (c *Client) do(ctx context.Context, method, path, apiVersion string, header http.Header, in, out interface{}, authenticated bool) error {
+	return c.doURL(ctx, method, c.HostURL+"/json"+path, apiVersion, header, in, out, authenticated)
+}
+
+// doURL sends a request to an absolute URL. It is used directly for the
+// endpoints that live outside /json, such as /oauth2.
+func (c *Client) doURL(ctx context.Context, method, url, apiVersion string, header http.Header, in, out interface{}, authenticated bool) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if apiVersion != "" {
+			req.Header.Set("Accept-API-Version", apiVersion)
+		}
+		var token string
+		if authenticated {
+			if token, err = c.sessionToken(ctx); err != nil {
+				return err
+			}
+			req.AddCookie(&http.Cookie{Name: c.CookieName(ctx), Value: token})
+		}
+
+		respBody, status, err := c.send(req)
+		if err != nil {
+			return err
+		}
+
+		// An expired session is answered with 401; sign in again once.
+		if status == http.StatusUnauthorized && authenticated && attempt == 0 {
+			c.expireToken(token)
+			continue
+		}
+		if status < 200 || status > 299 {
+			return &Error{StatusCode: status, Body: respBody}
+		}
+		if out == nil || len(respBody) == 0 {
+			return nil
+		}
+		return json.Unmarshal(respBody, out)
+	}
+}
+
+// getRaw fetches url without a session and returns the body unparsed. It is
+// used for the few endpoints that do not speak JSON, such as SAML metadata.
+func (c *Client) getRaw(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, status, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status > 299 {
+		return nil, &Error{StatusCode: status, Body: body}
+	}
+	return body, nil
+}
+
+func (c *Client) send(req *http.Request) ([]byte, int, error) {
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, res.StatusCode, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSessionTokenSignsInOnce(t *testing.T) {
+	var signIns atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/am/json/realms/root/authenticate":
+			n := signIns.Add(1)
+			// Keep the sign in slow so that every request waits for it.
+			time.Sleep(50 * time.Millisecond)
+			fmt.Fprintf(w, `{"tokenId": "token-%d"}`, n)
+		case "/am/json/serverinfo/*":
+			fmt.Fprint(w, `{"cookieName": "session"}`)
+		default:
+			// The first session is rejected, as if it had expired.
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value == "token-1" || cookie.Value != fmt.Sprintf("token-%d", signIns.Load()) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	host := server.URL + "/am"
+	c, err := NewClient(&host, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(n int) {
+		var wg sync.WaitGroup
+		errs := make(chan error, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- c.Get(context.Background(), "/realms/root/realm-config/services/session", ServiceAPIVersion, nil)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+	}
+
+	// Every request waits for the first sign in and gets a 401 with its
+	// session; only one of them signs in again.
+	get(10)
+	if got := signIns.Load(); got != 2 {
+		t.Errorf("signed in %d times, want 2", got)
+	}
+
+	get(10)
+	if got := signIns.Load(); got != 2 {
+		t.Errorf("signed in %d times with a valid session, want 2", got)
+	}
+}
+
+func TestIsIdentityCloud(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"https://openam-example.forgeblocks.com/am", true},
+		{"https://OPENAM-Example.forgeblocks.com:443/am", true},
+		{"https://openam-example.id.forgerock.io/am", true},
+		{"https://forgeblocks.com/am", false},
+		{"https://a.b.forgeblocks.com/am", false},
+		{"https://backstage.forgerock.io/am", false},
+		{"https://openam-.id.forgerock.io/am", false},
+		{"https://am.example.id.forgerock.io/am", false},
+		{"https://am.example.com/openam", false},
+	}
+	for _, tt := range tests {
+		c := &Client{HostURL: tt.host}
+		if got := c.IsIdentityCloud(); got != tt.want {
+			t.Errorf("IsIdentityCloud(%s) = %t, want %t", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalRealm(t *testing.T) {
+	tests := []struct {
+		realm   string
+		want    string
+		apiPath string
+	}{
+		{"", "/", "/realms/root"},
+		{"/", "/", "/realms/root"},
+		{"root", "/", "/realms/root"},
+		{"/realms/root", "/", "/realms/root"},
+		{"/alpha", "/alpha", "/realms/root/realms/alpha"},
+		{"alpha", "/alpha", "/realms/root/realms/alpha"},
+		{"/alpha/", "/alpha", "/realms/root/realms/alpha"},
+		{"root/alpha", "/alpha", "/realms/root/realms/alpha"},
+		{"alpha/sub", "/alpha/sub", "/realms/root/realms/alpha/realms/sub"},
+		{"/alpha/sub/", "/alpha/sub", "/realms/root/realms/alpha/realms/sub"},
+		{"/realms/root/realms/alpha/realms/sub", "/alpha/sub", "/realms/root/realms/alpha/realms/sub"},
+	}
+	for _, tt := range tests {
+		got, err := CanonicalRealm(tt.realm)
+		if err != nil || got != tt.want {
+			t.Errorf("CanonicalRealm(%q) = %q, %v, want %q", tt.realm, got, err, tt.want)
+		}
+		apiPath, err := RealmAPIPath(tt.realm)
+		if err != nil || apiPath != tt.apiPath {
+			t.Errorf("RealmAPIPath(%q) = %q, %v, want %q", tt.realm, apiPath, err, tt.apiPath)
+		}
+	}
+
+	for _, realm := range []string{
+		"/realms/alpha",
+		"/realms/root/alpha",
+		"/realms/root/realms",
+		"/alpha /sub",
+		"/alpha?x=1",
+		"/alpha%2fsub",
+	} {
+		if got, err := CanonicalRealm(realm); err == nil {
+			t.Errorf("CanonicalRealm(%q) = %q, want an error", realm, got)
+		}
+		if got, err := RealmAPIPath(realm); err == nil {
+			t.Errorf("RealmAPIPath(%q) = %q, want an error", realm, got)
+		}
+	}
+}
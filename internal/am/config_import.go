@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ConfigEntityKinds are the kinds of entity a configuration import writes, in
+// the order they are written: everything a journey or policy refers to comes
+// before it. Entities are deleted in the reverse order.
+var ConfigEntityKinds = []string{
+	"script",
+	"service",
+	"resourceType",
+	"policySet",
+	"policy",
+	"oauth2Client",
+	"node",
+	"journey",
+}
+
+// ConfigEntity is one object of a configuration export. ID is unique within
+// Kind; for nodes it is "type/id" and for service descendents
+// "service/type/id", as both are needed to address them.
+type ConfigEntity struct {
+	Kind   string
+	ID     string
+	Object map[string]interface{}
+}
+
+// Key identifies the entity within an import, as "kind/id".
+func (e ConfigEntity) Key() string {
+	return e.Kind + "/" + e.ID
+}
+
+// ParseConfigEntityKey splits a key returned by ConfigEntity.Key.
+func ParseConfigEntityKey(key string) (kind, id string, err error) {
+	kind, id, ok := strings.Cut(key, "/")
+	if !ok || id == "" || kindRank(kind) < 0 {
+		return "", "", fmt.Errorf("invalid entity key %q", key)
+	}
+	return kind, id, nil
+}
+
+func kindRank(kind string) int {
+	for i, k := range ConfigEntityKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// SortConfigEntityKeys sorts keys in write order.
+func SortConfigEntityKeys(keys []string) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		ki, _, _ := strings.Cut(keys[i], "/")
+		kj, _, _ := strings.Cut(keys[j], "/")
+		if kindRank(ki) != kindRank(kj) {
+			return kindRank(ki) < kindRank(kj)
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+// frodoConfigSections maps the sections of a Frodo export to entity kinds.
+// "trees" is handled separately, as a journey brings its nodes and scripts.
+var frodoConfigSections = map[string]string{
+	"script":       "script",
+	"service":      "service",
+	"resourcetype": "resourceType",
+	"policyset":    "policySet",
+	"policy":       "policy",
+	"application":  "oauth2Client",
+}
+
+// ParseFrodoConfig reads the entities of a Frodo realm export, either a
+// per-type export such as `frodo journey export` or `frodo script export`, or
+// a full export (`frodo config export --all`). A full export that covers
+// several realms under "realm" contributes the entities of realm only.
+// Sections the import cannot write, such as themes or email templates, are
+// returned as skipped rather than rejected.
+func ParseFrodoConfig(data []byte, realm string) ([]ConfigEntity, []string, error) {
+	var export map[string]json.RawMessage
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse Frodo export: %w", err)
+	}
+
+	if raw, ok := export["realm"]; ok {
+		var realms map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &realms); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse the realms of the Frodo export: %w", err)
+		}
+		parts, err := ParseRealm(realm)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := "root"
+		if len(parts) > 0 {
+			name = parts[len(parts)-1]
+		}
+		if export = realms[name]; export == nil {
+			return nil, nil, fmt.Errorf("Frodo export does not contain realm %q", name)
+		}
+	}
+
+	entities := map[string]ConfigEntity{}
+	add := func(e ConfigEntity) {
+		Normalize(e.Object)
+		entities[e.Key()] = e
+	}
+	var skipped []string
+
+	for section, raw := range export {
+		kind, known := frodoConfigSections[section]
+		switch {
+		case section == "meta" || string(raw) == "null":
+		case section == "trees":
+			if err := parseFrodoTrees(raw, add); err != nil {
+				return nil, nil, err
+			}
+		case known:
+			var objects map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &objects); err != nil {
+				return nil, nil, fmt.Errorf("unable to parse section %q of the Frodo export: %w", section, err)
+			}
+			for id, raw := range objects {
+				if err := parseFrodoEntity(kind, id, raw, add); err != nil {
+					return nil, nil, fmt.Errorf("unable to parse %s %q of the Frodo export: %w", kind, id, err)
+				}
+			}
+		default:
+			var objects map[string]json.RawMessage
+			if json.Unmarshal(raw, &objects) != nil || len(objects) > 0 {
+				skipped = append(skipped, section)
+			}
+		}
+	}
+	sort.Strings(skipped)
+
+	if len(entities) == 0 {
+		return nil, skipped, fmt.Errorf("Frodo export contains nothing to import")
+	}
+
+	return sortedConfigEntities(entities), skipped, nil
+}
+
+// sortedConfigEntities returns entities in write order.
+func sortedConfigEntities(entities map[string]ConfigEntity) []ConfigEntity {
+	result := make([]ConfigEntity, 0, len(entities))
+	for _, e := range entities {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if kindRank(a.Kind) != kindRank(b.Kind) {
+			return kindRank(a.Kind) < kindRank(b.Kind)
+		}
+		// Nodes placed inside a Page node must exist before the Page node.
+		if isInnerNode(a) != isInnerNode(b) {
+			return isInnerNode(a)
+		}
+		return a.Key() < b.Key()
+	})
+	for _, e := range result {
+		delete(e.Object, "_inner")
+	}
+	return result
+}
+
+func isInnerNode(e ConfigEntity) bool {
+	inner, _ := e.Object["_inner"].(bool)
+	return inner
+}
+
+func parseFrodoTrees(raw json.RawMessage, add func(ConfigEntity)) error {
+	var trees map[string]struct {
+		Tree       json.RawMessage            `json:"tree"`
+		Nodes      map[string]json.RawMessage `json:"nodes"`
+		InnerNodes map[string]json.RawMessage `json:"innerNodes"`
+		Scripts    map[string]json.RawMessage `json:"scripts"`
+	}
+	if err := json.Unmarshal(raw, &trees); err != nil {
+		return fmt.Errorf("unable to parse the journeys of the Frodo export: %w", err)
+	}
+
+	for name, t := range trees {
+		if len(t.Tree) == 0 {
+			return fmt.Errorf("journey %q of the Frodo export has no tree", name)
+		}
+		if err := parseFrodoEntity("journey", name, t.Tree, add); err != nil {
+			return fmt.Errorf("unable to parse journey %q of the Frodo export: %w", name, err)
+		}
+		for id, raw := range t.Scripts {
+			if err := parseFrodoEntity("script", id, raw, add); err != nil {
+				return fmt.Errorf("unable to parse script %q of journey %q: %w", id, name, err)
+			}
+		}
+		for inner, nodes := range map[bool]map[string]json.RawMessage{false: t.Nodes, true: t.InnerNodes} {
+			for id, raw := range nodes {
+				nodeType := NodeTypeOf(raw)
+				if nodeType == "" {
+					return fmt.Errorf("node %q of journey %q has no _type", id, name)
+				}
+				obj, err := normalizedObject(raw)
+				if err != nil {
+					return fmt.Errorf("unable to parse node %q of journey %q: %w", id, name, err)
+				}
+				obj["_inner"] = inner
+				add(ConfigEntity{Kind: "node", ID: nodeType + "/" + id, Object: obj})
+			}
+		}
+	}
+	return nil
+}
+
+func parseFrodoEntity(kind, id string, raw json.RawMessage, add func(ConfigEntity)) error {
+	obj, err := normalizedObject(raw)
+	if err != nil {
+		return err
+	}
+	if objID, ok := obj["_id"].(string); ok && objID != "" && kind != "service" {
+		id = objID
+	}
+
+	switch kind {
+	case "script":
+		var s struct {
+			Source json.RawMessage `json:"script"`
+		}
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		source, err := frodoScriptSource(s.Source)
+		if err != nil {
+			return err
+		}
+		obj["script"] = base64.StdEncoding.EncodeToString([]byte(source))
+	case "service":
+		// Sub-configurations, such as the secret stores of a secret store
+		// service, are written separately below the service.
+		if descendents, ok := obj["nextDescendents"].([]interface{}); ok {
+			delete(obj, "nextDescendents")
+			for _, d := range descendents {
+				child, ok := d.(map[string]interface{})
+				childID, _ := child["_id"].(string)
+				if !ok || childID == "" || typeID(child) == "" {
+					return fmt.Errorf("service %q has a descendent without _id or _type", id)
+				}
+				add(ConfigEntity{Kind: "service", ID: id + "/" + typeID(child) + "/" + childID, Object: child})
+			}
+		}
+	}
+
+	add(ConfigEntity{Kind: kind, ID: id, Object: obj})
+	return nil
+}
+
+// configEntityPath returns the REST path and API version of an entity.
+func configEntityPath(realm, kind, id string) (string, string, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return "", "", err
+	}
+	escaped := escapePath(id)
+	switch kind {
+	case "script":
+		return base + "/scripts/" + escaped, ServiceAPIVersion, nil
+	case "service":
+		return base + "/realm-config/services/" + escaped, ServiceAPIVersion, nil
+	case "resourceType":
+		return base + "/resourcetypes/" + escaped, ServiceAPIVersion, nil
+	case "policySet":
+		return base + "/applications/" + escaped, PolicyAPIVersion, nil
+	case "policy":
+		return base + "/policies/" + escaped, PolicyAPIVersion, nil
+	case "oauth2Client":
+		return base + "/realm-config/agents/OAuth2Client/" + escaped, OAuth2ClientAPIVersion, nil
+	case "node":
+		return base + "/realm-config/authentication/authenticationtrees/nodes/" + escaped, JourneyAPIVersion, nil
+	default:
+		return base + "/realm-config/authentication/authenticationtrees/trees/" + escaped, JourneyAPIVersion, nil
+	}
+}
+
+// escapePath escapes each "/"-separated segment of id.
+func escapePath(id string) string {
+	parts := strings.Split(id, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// GetConfigEntity reads an entity of realm. The result is normalized.
+func (c *Client) GetConfigEntity(ctx context.Context, realm, kind, id string) (map[string]interface{}, error) {
+	path, apiVersion, err := configEntityPath(realm, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := c.Get(ctx, path, apiVersion, &raw); err != nil {
+		return nil, err
+	}
+	return normalizedObject(raw)
+}
+
+// PutConfigEntity creates or replaces an entity of realm and returns the
+// normalized result. Services are created with _action=create when the realm
+// does not have them yet, as AM does not create them on PUT.
+func (c *Client) PutConfigEntity(ctx context.Context, realm string, e ConfigEntity) (map[string]interface{}, error) {
+	path, apiVersion, err := configEntityPath(realm, e.Kind, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if e.Kind == "service" && !strings.Contains(e.ID, "/") {
+		err = c.putService(ctx, path, e.Object, &raw)
+	} else {
+		err = c.Put(ctx, path, apiVersion, e.Object, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return c.GetConfigEntity(ctx, realm, e.Kind, e.ID)
+	}
+	return normalizedObject(raw)
+}
+
+// DeleteConfigEntity deletes an entity of realm, treating an already missing
+// entity as deleted.
+func (c *Client) DeleteConfigEntity(ctx context.Context, realm, kind, id string) error {
+	path, apiVersion, err := configEntityPath(realm, kind, id)
+	if err != nil {
+		return err
+	}
+	if err := c.Delete(ctx, path, apiVersion, nil); err != nil && !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// ProjectedHash is the ContentHash of the members of live that desired also
+// has. AM adds defaults and omits secrets when it returns an entity, so the
+// live object is only ever compared with what the same import wrote before.
+func ProjectedHash(live, desired map[string]interface{}) (string, error) {
+	projected := make(map[string]interface{}, len(desired))
+	for k := range desired {
+		if v, ok := live[k]; ok {
+			projected[k] = v
+		}
+	}
+	return ContentHash(projected)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ExportSections are the parts of a realm's configuration ExportRealm can
+// read, in the order they appear in the export.
+var ExportSections = []string{
+	"services",
+	"journeys",
+	"nodes",
+	"scripts",
+	"oauth2Clients",
+	"agents",
+	"policySets",
+	"policies",
+	"resourceTypes",
+}
+
+// VolatileFields are stripped from exported objects because they change
+// without a configuration change or differ between otherwise equal
+// environments.
+var VolatileFields = []string{"_rev", "createdBy", "creationDate", "lastModifiedBy", "lastModifiedDate"}
+
+// RealmExport is a realm's configuration keyed by section and object ID.
+// Agents are keyed by "type/id"; OAuth 2.0 clients have their own section.
+type RealmExport map[string]map[string]interface{}
+
+// ExportRealm reads the requested sections of realm. Unknown sections are
+// rejected; sections an older server does not have are exported empty.
+func (c *Client) ExportRealm(ctx context.Context, realm string, sections []string) (RealmExport, error) {
+	export := RealmExport{}
+	for _, section := range sections {
+		objects, err := c.exportSection(ctx, realm, section)
+		if IsNotFound(err) {
+			objects, err = map[string]interface{}{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to export %s: %w", section, err)
+		}
+		export[section] = objects
+	}
+	return export, nil
+}
+
+func (c *Client) exportSection(ctx context.Context, realm, section string) (map[string]interface{}, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	switch section {
+	case "services":
+		return c.exportDescendents(ctx, base+"/realm-config/services", func(id, typ string) string { return typ })
+	case "journeys":
+		return c.exportQuery(ctx, base+"/realm-config/authentication/authenticationtrees/trees", JourneyAPIVersion)
+	case "nodes":
+		return c.exportQuery(ctx, base+"/realm-config/authentication/authenticationtrees/nodes", JourneyAPIVersion)
+	case "scripts":
+		return c.exportQuery(ctx, base+"/scripts", ServiceAPIVersion)
+	case "oauth2Clients":
+		return c.exportQuery(ctx, base+"/realm-config/agents/OAuth2Client", OAuth2ClientAPIVersion)
+	case "agents":
+		agents, err := c.exportDescendents(ctx, base+"/realm-config/agents", func(id, typ string) string { return typ + "/" + id })
+		if err != nil {
+			return nil, err
+		}
+		for key, agent := range agents {
+			if obj, ok := agent.(map[string]interface{}); ok && typeID(obj) == "OAuth2Client" {
+				delete(agents, key)
+			}
+		}
+		return agents, nil
+	case "policySets":
+		return c.exportQuery(ctx, base+"/applications", PolicyAPIVersion)
+	case "policies":
+		return c.exportQuery(ctx, base+"/policies", PolicyAPIVersion)
+	case "resourceTypes":
+		return c.exportQuery(ctx, base+"/resourcetypes", ServiceAPIVersion)
+	default:
+		return nil, fmt.Errorf("unknown section %q", section)
+	}
+}
+
+func (c *Client) exportQuery(ctx context.Context, path, apiVersion string) (map[string]interface{}, error) {
+	results, err := c.Query(ctx, path, apiVersion, QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+	objects := make(map[string]interface{}, len(results))
+	for _, raw := range results {
+		obj, err := normalizedObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		id, _ := obj["_id"].(string)
+		objects[id] = obj
+	}
+	return objects, nil
+}
+
+// exportDescendents reads every instance below a configuration collection in
+// one request. key derives the export key from the instance and type IDs.
+func (c *Client) exportDescendents(ctx context.Context, path string, key func(id, typ string) string) (map[string]interface{}, error) {
+	var result struct {
+		Result []json.RawMessage `json:"result"`
+	}
+	if err := c.Post(ctx, path+"?_action=nextdescendents", ServiceAPIVersion, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	objects := make(map[string]interface{}, len(result.Result))
+	for _, raw := range result.Result {
+		obj, err := normalizedObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		id, _ := obj["_id"].(string)
+		objects[key(id, typeID(obj))] = obj
+	}
+	return objects, nil
+}
+
+func typeID(obj map[string]interface{}) string {
+	t, _ := obj["_type"].(map[string]interface{})
+	id, _ := t["_id"].(string)
+	return id
+}
+
+func normalizedObject(raw json.RawMessage) (map[string]interface{}, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+	Normalize(obj)
+	return obj, nil
+}
+
+// Normalize removes VolatileFields from v and every object nested in it.
+func Normalize(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, f := range VolatileFields {
+			delete(t, f)
+		}
+		for _, child := range t {
+			Normalize(child)
+		}
+	case []interface{}:
+		for _, child := range t {
+			Normalize(child)
+		}
+	}
+}
+
+// CanonicalJSON encodes v with sorted object keys, no insignificant
+// whitespace and without HTML escaping, so equal configurations always
+// produce identical bytes.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// ContentHash is the hex SHA-256 of the canonical JSON encoding of v.
+func ContentHash(v interface{}) (string, error) {
+	b, err := CanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FrodoJourney is one journey of a Frodo export (`frodo journey export`):
+// the tree itself, the configuration of the nodes it places, of the nodes
+// placed inside its Page nodes, and the scripts those nodes run.
+type FrodoJourney struct {
+	Tree       Journey
+	Nodes      map[string]json.RawMessage
+	InnerNodes map[string]json.RawMessage
+	Scripts    map[string]Script
+}
+
+// frodoJourney is the wire form of FrodoJourney. Frodo writes script
+// sources as base64, as plain text or as an array of lines depending on its
+// version and options, so scripts are decoded separately.
+type frodoJourney struct {
+	Tree       *Journey                   `json:"tree"`
+	Nodes      map[string]json.RawMessage `json:"nodes"`
+	InnerNodes map[string]json.RawMessage `json:"innerNodes"`
+	Scripts    map[string]json.RawMessage `json:"scripts"`
+}
+
+// ParseFrodoJourneys reads a Frodo journey export. Both the current layout,
+// which keys journeys by name under "trees", and the older single-journey
+// layout with "tree" at the top level are understood.
+func ParseFrodoJourneys(data []byte) (map[string]*FrodoJourney, error) {
+	var export struct {
+		frodoJourney
+		Trees map[string]frodoJourney `json:"trees"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("unable to parse Frodo export: %w", err)
+	}
+
+	wire := export.Trees
+	if wire == nil && export.Tree != nil {
+		wire = map[string]frodoJourney{export.Tree.ID: export.frodoJourney}
+	}
+	if len(wire) == 0 {
+		return nil, fmt.Errorf("Frodo export contains no journey")
+	}
+
+	journeys := make(map[string]*FrodoJourney, len(wire))
+	for name, w := range wire {
+		if w.Tree == nil {
+			return nil, fmt.Errorf("journey %q of the Frodo export has no tree", name)
+		}
+		j := &FrodoJourney{
+			Tree:       *w.Tree,
+			Nodes:      w.Nodes,
+			InnerNodes: w.InnerNodes,
+			Scripts:    make(map[string]Script, len(w.Scripts)),
+		}
+		if j.Tree.ID == "" {
+			j.Tree.ID = name
+		}
+		for id, raw := range w.Scripts {
+			s, err := parseFrodoScript(raw)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse script %q of journey %q: %w", id, name, err)
+			}
+			j.Scripts[id] = s
+		}
+		journeys[j.Tree.ID] = j
+	}
+	return journeys, nil
+}
+
+// InnerJourneys returns the IDs of the journeys run by the Inner Tree
+// Evaluator nodes of j, sorted.
+func (j *FrodoJourney) InnerJourneys() []string {
+	var ids []string
+	for id, node := range j.Tree.Nodes {
+		if node.NodeType != "InnerTreeEvaluatorNode" {
+			continue
+		}
+		var config struct {
+			Tree string `json:"tree"`
+		}
+		if raw, ok := j.Nodes[id]; ok && json.Unmarshal(raw, &config) == nil && config.Tree != "" {
+			ids = append(ids, config.Tree)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// NodeConfig returns the configuration of a node placed in j, directly or
+// inside a Page node, without the _id, _type and _outcomes bookkeeping and
+// without VolatileFields.
+func (j *FrodoJourney) NodeConfig(id string) (map[string]interface{}, bool, error) {
+	raw, ok := j.Nodes[id]
+	if !ok {
+		raw, ok = j.InnerNodes[id]
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	config, err := normalizedObject(raw)
+	if err != nil {
+		return nil, true, fmt.Errorf("unable to parse the configuration of node %q: %w", id, err)
+	}
+	delete(config, "_id")
+	delete(config, "_type")
+	delete(config, "_outcomes")
+	return config, true, nil
+}
+
+// NodeTypeOf returns the node type recorded in a node configuration, which
+// for nodes inside Page nodes is the only place it appears.
+func NodeTypeOf(raw json.RawMessage) string {
+	var node struct {
+		Type struct {
+			ID string `json:"_id"`
+		} `json:"_type"`
+	}
+	_ = json.Unmarshal(raw, &node)
+	return node.Type.ID
+}
+
+func parseFrodoScript(raw json.RawMessage) (Script, error) {
+	var s struct {
+		Script
+		Source json.RawMessage `json:"script"`
+	}
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return Script{}, err
+	}
+
+	source, err := frodoScriptSource(s.Source)
+	if err != nil {
+		return Script{}, err
+	}
+
+	script := s.Script
+	script.Script = base64.StdEncoding.EncodeToString([]byte(source))
+	return script, nil
+}
+
+// frodoScriptSource decodes the "script" member of a Frodo script export.
+func frodoScriptSource(raw json.RawMessage) (string, error) {
+	var source string
+	var lines []string
+	switch {
+	case json.Unmarshal(raw, &lines) == nil:
+		source = strings.Join(lines, "\n")
+	case json.Unmarshal(raw, &source) == nil:
+		// Older exports keep AM's base64 encoding; newer ones decode it.
+		if b, err := base64.StdEncoding.DecodeString(source); err == nil && utf8.Valid(b) {
+			source = string(b)
+		}
+	case len(raw) > 0:
+		return "", fmt.Errorf("script source must be a string or an array of lines")
+	}
+	return source, nil
+}
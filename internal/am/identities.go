@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// IdentityAPIVersion is the Accept-API-Version used for users and groups.
+const IdentityAPIVersion = "protocol=2.1,resource=3.0"
+
+// User is an identity in a realm's identity store. AM returns most attributes
+// as arrays of strings; anything not mapped to a field ends up in Attributes.
+type User struct {
+	ID         string
+	Username   string
+	Password   string
+	Mail       string
+	Status     string
+	DN         string
+	Attributes map[string][]string
+}
+
+// Group is a group in a realm's identity store.
+type Group struct {
+	ID           string          `json:"_id,omitempty"`
+	Username     string          `json:"username"`
+	UniqueMember []string        `json:"uniquemember"`
+	Privileges   map[string]bool `json:"privileges,omitempty"`
+}
+
+// Identity is a user or group read from a query. Attributes never contain
+// SensitiveIdentityAttributes.
+type Identity struct {
+	ID         string
+	Username   string
+	Attributes map[string][]string
+}
+
+// SensitiveIdentityAttributes are never read into an Identity: password
+// hashes, knowledge-based answers and the secrets of registered devices.
+// Names are compared case-insensitively.
+var SensitiveIdentityAttributes = []string{
+	"userPassword",
+	"unicodePwd",
+	"pwdHistory",
+	"kbaInfo",
+	"oathDeviceProfiles",
+	"pushDeviceProfiles",
+	"webauthnDeviceProfiles",
+	"deviceProfiles",
+	"devicePrintProfiles",
+	"sunAMAuthInvalidAttemptsData",
+}
+
+// IsSensitiveIdentityAttribute reports whether name is one of
+// SensitiveIdentityAttributes.
+func IsSensitiveIdentityAttribute(name string) bool {
+	for _, s := range SensitiveIdentityAttributes {
+		if strings.EqualFold(s, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON reads the attribute map returned by AM, dropping sensitive
+// attributes and anything that is not a string or list of strings.
+func (i *Identity) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	i.Attributes = map[string][]string{}
+	for k, v := range raw {
+		values, ok := stringValues(v)
+		if !ok || IsSensitiveIdentityAttribute(k) {
+			continue
+		}
+		switch k {
+		case "_id":
+			i.ID = first(values)
+		case "username":
+			i.Username = first(values)
+		case "_rev":
+		default:
+			i.Attributes[k] = values
+		}
+	}
+	return nil
+}
+
+// MarshalJSON flattens the user into the attribute map AM expects.
+func (u User) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{}
+	for k, v := range u.Attributes {
+		m[k] = v
+	}
+	m["username"] = u.Username
+	m["mail"] = []string{}
+	if u.Mail != "" {
+		m["mail"] = []string{u.Mail}
+	}
+	if u.Status != "" {
+		m["inetUserStatus"] = []string{u.Status}
+	}
+	if u.Password != "" {
+		m["userpassword"] = u.Password
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON reads the attribute map returned by AM. Password hashes are
+// never kept, even when the server is configured to return them.
+func (u *User) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	u.Attributes = map[string][]string{}
+	for k, v := range raw {
+		values, ok := stringValues(v)
+		if !ok {
+			continue
+		}
+		switch k {
+		case "_id":
+			u.ID = first(values)
+		case "username":
+			u.Username = first(values)
+		case "mail":
+			u.Mail = first(values)
+		case "inetUserStatus":
+			u.Status = first(values)
+		case "dn":
+			u.DN = first(values)
+		case "userpassword", "userPassword", "_rev":
+		default:
+			u.Attributes[k] = values
+		}
+	}
+	return nil
+}
+
+// stringValues decodes a JSON string or array of strings.
+func stringValues(v json.RawMessage) ([]string, bool) {
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return []string{s}, true
+	}
+	var a []string
+	if err := json.Unmarshal(v, &a); err == nil {
+		return a, true
+	}
+	return nil, false
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// FirstRDNValue returns the value of the first RDN of a DN, which for AM
+// identities is the identity ID, e.g. "demo" for "uid=demo,ou=people,...".
+func FirstRDNValue(dn string) string {
+	rdn := strings.SplitN(dn, ",", 2)[0]
+	if i := strings.Index(rdn, "="); i >= 0 {
+		return rdn[i+1:]
+	}
+	return rdn
+}
+
+// GetUser reads a user of the client's realm.
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	var u User
+	if err := c.Get(ctx, c.RealmPath("/users/"+url.PathEscape(id)), IdentityAPIVersion, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// CreateUser creates a user in the client's realm.
+func (c *Client) CreateUser(ctx context.Context, u User) (*User, error) {
+	var result User
+	if err := c.Post(ctx, c.RealmPath("/users?_action=create"), IdentityAPIVersion, u, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateUser replaces the attributes present in u. Password changes are only
+// sent when u.Password is set.
+func (c *Client) UpdateUser(ctx context.Context, id string, u User) (*User, error) {
+	var result User
+	if err := c.Put(ctx, c.RealmPath("/users/"+url.PathEscape(id)), IdentityAPIVersion, u, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteUser deletes a user of the client's realm.
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.Delete(ctx, c.RealmPath("/users/"+url.PathEscape(id)), IdentityAPIVersion, nil)
+}
+
+// QueryUsers runs a CREST query against the users of the client's realm.
+func (c *Client) QueryUsers(ctx context.Context, params QueryParams) ([]User, error) {
+	results, err := c.Query(ctx, c.RealmPath("/users"), IdentityAPIVersion, params)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]User, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &users[i]); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// GetGroup reads a group of the client's realm.
+func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
+	var g Group
+	if err := c.Get(ctx, c.RealmPath("/groups/"+url.PathEscape(id)), IdentityAPIVersion, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// CreateGroup creates a group named g.Username in the client's realm.
+func (c *Client) CreateGroup(ctx context.Context, g Group) (*Group, error) {
+	header := http.Header{}
+	header.Set("If-None-Match", "*")
+
+	var result Group
+	if err := c.Do(ctx, http.MethodPut, c.RealmPath("/groups/"+url.PathEscape(g.Username)), IdentityAPIVersion, header, g, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateGroup replaces the members and privileges of a group.
+func (c *Client) UpdateGroup(ctx context.Context, id string, g Group) (*Group, error) {
+	var result Group
+	if err := c.Put(ctx, c.RealmPath("/groups/"+url.PathEscape(id)), IdentityAPIVersion, g, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteGroup deletes a group of the client's realm.
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	return c.Delete(ctx, c.RealmPath("/groups/"+url.PathEscape(id)), IdentityAPIVersion, nil)
+}
+
+// QueryIdentities runs a CREST query against the users or groups of realm;
+// kind is "users" or "groups".
+func (c *Client) QueryIdentities(ctx context.Context, realm, kind string, params QueryParams) ([]Identity, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.Query(ctx, base+"/"+kind, IdentityAPIVersion, params)
+	if err != nil {
+		return nil, err
+	}
+	identities := make([]Identity, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &identities[i]); err != nil {
+			return nil, err
+		}
+	}
+	return identities, nil
+}
+
+// SessionIdentity is the user the client's session belongs to.
+type SessionIdentity struct {
+	ID    string `json:"id"`
+	Realm string `json:"realm"`
+	DN    string `json:"dn"`
+}
+
+// IDFromSession returns the identity of the signed in administrator.
+func (c *Client) IDFromSession(ctx context.Context) (*SessionIdentity, error) {
+	var id SessionIdentity
+	if err := c.Post(ctx, "/realms/root/users?_action=idFromSession", IdentityAPIVersion, map[string]interface{}{}, &id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
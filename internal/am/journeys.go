@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// JourneyAPIVersion is the Accept-API-Version used for authentication trees
+// and their nodes.
+const JourneyAPIVersion = "protocol=2.1,resource=1.0"
+
+// Journey is an authentication tree.
+type Journey struct {
+	ID               string                 `json:"_id"`
+	Description      string                 `json:"description,omitempty"`
+	EntryNodeID      string                 `json:"entryNodeId"`
+	IdentityResource string                 `json:"identityResource,omitempty"`
+	UIConfig         map[string]string      `json:"uiConfig,omitempty"`
+	InnerTreeOnly    bool                   `json:"innerTreeOnly,omitempty"`
+	Enabled          *bool                  `json:"enabled,omitempty"`
+	Nodes            map[string]JourneyNode `json:"nodes"`
+	StaticNodes      map[string]JourneyNode `json:"staticNodes,omitempty"`
+}
+
+// IsEnabled reports whether the journey can be used. Releases before 7.0 have
+// no enabled flag, so every journey counts as enabled there.
+func (j Journey) IsEnabled() bool {
+	return j.Enabled == nil || *j.Enabled
+}
+
+// JourneyNode is a node as placed in a journey: its type, label, canvas
+// position and the node each outcome leads to.
+type JourneyNode struct {
+	NodeType    string            `json:"nodeType,omitempty"`
+	DisplayName string            `json:"displayName,omitempty"`
+	Connections map[string]string `json:"connections,omitempty"`
+	X           json.Number       `json:"x,omitempty"`
+	Y           json.Number       `json:"y,omitempty"`
+}
+
+// QueryJourneys returns the journeys of realm that match params.
+func (c *Client) QueryJourneys(ctx context.Context, realm string, params QueryParams) ([]Journey, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.Query(ctx, base+"/realm-config/authentication/authenticationtrees/trees", JourneyAPIVersion, params)
+	if err != nil {
+		return nil, err
+	}
+	journeys := make([]Journey, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &journeys[i]); err != nil {
+			return nil, err
+		}
+	}
+	return journeys, nil
+}
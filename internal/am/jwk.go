@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+)
+
+// JWKSet is a JSON Web Key Set as published by the jwk_uri endpoint.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public JSON Web Key. Only the members AM publishes are modelled.
+type JWK struct {
+	KeyID     string   `json:"kid,omitempty"`
+	KeyType   string   `json:"kty"`
+	Algorithm string   `json:"alg,omitempty"`
+	Use       string   `json:"use,omitempty"`
+	N         string   `json:"n,omitempty"`
+	E         string   `json:"e,omitempty"`
+	Curve     string   `json:"crv,omitempty"`
+	X         string   `json:"x,omitempty"`
+	Y         string   `json:"y,omitempty"`
+	X5C       []string `json:"x5c,omitempty"`
+	X5T       string   `json:"x5t,omitempty"`
+	X5TS256   string   `json:"x5t#S256,omitempty"`
+}
+
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// PublicKey decodes the key material of k.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", k.KeyID, err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", k.KeyID, err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, ok := curves[k.Curve]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q of key %q", k.Curve, k.KeyID)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate of key %q: %w", k.KeyID, err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate of key %q: %w", k.KeyID, err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q of key %q", k.Curve, k.KeyID)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key of key %q", k.KeyID)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q of key %q", k.KeyType, k.KeyID)
+	}
+}
+
+// PEM returns the public key of k as a PEM encoded PKIX structure.
+func (k JWK) PEM() (string, error) {
+	pub, err := k.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// Certificate returns the first certificate of the x5c chain, or nil when the
+// key carries no certificate.
+func (k JWK) Certificate() (*x509.Certificate, error) {
+	if len(k.X5C) == 0 {
+		return nil, nil
+	}
+	// x5c uses standard base64, not the URL alphabet of the other members.
+	der, err := base64.StdEncoding.DecodeString(k.X5C[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate of key %q: %w", k.KeyID, err)
+	}
+	return x509.ParseCertificate(der)
+}
+
+// JWKFromPEM builds a public JWK from PEM data. The data may hold a public
+// key, a certificate chain whose first certificate holds the key, or a
+// private key, of which only the public half is used. Certificates are
+// published in x5c with their x5t and x5t#S256 thumbprints. The algorithm is
+// the usual one for the key type; KeyID and Use are left for the caller.
+func JWKFromPEM(data []byte) (JWK, error) {
+	var pub crypto.PublicKey
+	var chain []*x509.Certificate
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return JWK{}, fmt.Errorf("invalid certificate: %w", err)
+			}
+			chain = append(chain, cert)
+			continue
+		case "PUBLIC KEY", "RSA PUBLIC KEY", "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+			if pub != nil {
+				return JWK{}, fmt.Errorf("PEM data holds more than one key")
+			}
+			key, err := parsePEMKey(block)
+			if err != nil {
+				return JWK{}, err
+			}
+			pub = key
+		default:
+			return JWK{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+		}
+	}
+
+	if len(chain) > 0 {
+		if pub != nil && !publicKeysEqual(pub, chain[0].PublicKey) {
+			return JWK{}, fmt.Errorf("the key does not belong to the first certificate")
+		}
+		pub = chain[0].PublicKey
+	}
+	if pub == nil {
+		return JWK{}, fmt.Errorf("no key or certificate found in PEM data")
+	}
+
+	k, err := publicJWK(pub)
+	if err != nil {
+		return JWK{}, err
+	}
+	for _, cert := range chain {
+		k.X5C = append(k.X5C, base64.StdEncoding.EncodeToString(cert.Raw))
+	}
+	if len(chain) > 0 {
+		// x5t is defined as a SHA-1 thumbprint; x5t#S256 is its successor.
+		sum1 := sha1.Sum(chain[0].Raw)
+		sum256 := sha256.Sum256(chain[0].Raw)
+		k.X5T = base64.RawURLEncoding.EncodeToString(sum1[:])
+		k.X5TS256 = base64.RawURLEncoding.EncodeToString(sum256[:])
+	}
+	return k, nil
+}
+
+// Thumbprint returns the RFC 7638 SHA-256 thumbprint of k, which is commonly
+// used as its kid.
+func (k JWK) Thumbprint() (string, error) {
+	var members interface{}
+	// The required members only, in lexicographic order.
+	switch k.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{k.E, k.KeyType, k.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{k.Curve, k.KeyType, k.X, k.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{k.Curve, k.KeyType, k.X}
+	default:
+		return "", fmt.Errorf("unsupported key type %q", k.KeyType)
+	}
+	b, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func parsePEMKey(block *pem.Block) (crypto.PublicKey, error) {
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", block.Type, err)
+	}
+	if private, ok := key.(crypto.Signer); ok {
+		return private.Public(), nil
+	}
+	return key, nil
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	k, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(b)
+}
+
+func publicJWK(pub crypto.PublicKey) (JWK, error) {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType:   "RSA",
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		params := key.Curve.Params()
+		size := (params.BitSize + 7) / 8
+		algorithms := map[string]string{"P-256": "ES256", "P-384": "ES384", "P-521": "ES512"}
+		alg, ok := algorithms[params.Name]
+		if !ok {
+			return JWK{}, fmt.Errorf("unsupported curve %q", params.Name)
+		}
+		return JWK{
+			KeyType:   "EC",
+			Algorithm: alg,
+			Curve:     params.Name,
+			X:         base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+			Y:         base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType:   "OKP",
+			Algorithm: "EdDSA",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	default:
+		return JWK{}, fmt.Errorf("unsupported key type %T", pub)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
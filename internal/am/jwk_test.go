@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+// rfc7638Key is the RSA key of the example in RFC 7638, section 3.1.
+var rfc7638Key = JWK{
+	KeyType: "RSA",
+	N: "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn" +
+		"64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbI" +
+		"SD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	E: "AQAB",
+}
+
+// rfc7638Thumbprint is the thumbprint RFC 7638 gives for rfc7638Key.
+const rfc7638Thumbprint = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+
+func TestThumbprint(t *testing.T) {
+	got, err := rfc7638Key.Thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != rfc7638Thumbprint {
+		t.Errorf("Thumbprint = %s, want %s", got, rfc7638Thumbprint)
+	}
+}
+
+func TestJWKFromPEMThumbprint(t *testing.T) {
+	pub, err := rfc7638Key.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub.(*rsa.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := JWKFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.KeyType != "RSA" || k.Algorithm != "RS256" || k.N != rfc7638Key.N || k.E != rfc7638Key.E {
+		t.Errorf("JWKFromPEM = %+v, want the members of the RFC 7638 key", k)
+	}
+	got, err := k.Thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != rfc7638Thumbprint {
+		t.Errorf("Thumbprint = %s, want %s", got, rfc7638Thumbprint)
+	}
+}
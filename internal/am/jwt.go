@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+)
+
+// SignJWT signs claims with the PEM encoded private key, using the usual
+// algorithm for the key type: RS256, ES256, ES384, ES512 or EdDSA.
+func SignJWT(claims interface{}, privateKeyPEM []byte, keyID string) (string, error) {
+	key, err := parsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		return "", err
+	}
+	k, err := publicJWK(key.Public())
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+		Kid string `json:"kid,omitempty"`
+	}{k.Algorithm, "JWT", keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		sum := sha256.Sum256([]byte(input))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	case *ecdsa.PrivateKey:
+		signature, err = signECDSA(key, k.Algorithm, []byte(input))
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, []byte(input))
+	}
+	if err != nil {
+		return "", err
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// signECDSA produces the fixed-size r||s signature JWS uses instead of ASN.1.
+func signECDSA(key *ecdsa.PrivateKey, alg string, input []byte) ([]byte, error) {
+	var digest []byte
+	switch alg {
+	case "ES256":
+		sum := sha256.Sum256(input)
+		digest = sum[:]
+	case "ES384":
+		sum := sha512.Sum384(input)
+		digest = sum[:]
+	default:
+		sum := sha512.Sum512(input)
+		digest = sum[:]
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...), nil
+}
+
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("expected a private key, got a PEM block of type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", block.Type, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestSignJWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey := func(curve elliptic.Curve) *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+
+	tests := []struct {
+		alg    string
+		key    crypto.Signer
+		verify func(pub crypto.PublicKey, input, signature []byte) bool
+	}{
+		{"RS256", rsaKey, func(pub crypto.PublicKey, input, signature []byte) bool {
+			sum := sha256.Sum256(input)
+			return rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, sum[:], signature) == nil
+		}},
+		{"ES256", ecKey(elliptic.P256()), func(pub crypto.PublicKey, input, signature []byte) bool {
+			sum := sha256.Sum256(input)
+			return verifyECDSA(pub.(*ecdsa.PublicKey), sum[:], signature, 32)
+		}},
+		{"ES384", ecKey(elliptic.P384()), func(pub crypto.PublicKey, input, signature []byte) bool {
+			sum := sha512.Sum384(input)
+			return verifyECDSA(pub.(*ecdsa.PublicKey), sum[:], signature, 48)
+		}},
+		{"ES512", ecKey(elliptic.P521()), func(pub crypto.PublicKey, input, signature []byte) bool {
+			sum := sha512.Sum512(input)
+			return verifyECDSA(pub.(*ecdsa.PublicKey), sum[:], signature, 66)
+		}},
+		{"EdDSA", edKey, func(pub crypto.PublicKey, input, signature []byte) bool {
+			return ed25519.Verify(pub.(ed25519.PublicKey), input, signature)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			der, err := x509.MarshalPKCS8PrivateKey(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := SignJWT(map[string]string{"sub": "client"}, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), "key-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parts := strings.Split(token, ".")
+			if len(parts) != 3 {
+				t.Fatalf("SignJWT = %q, want three parts", token)
+			}
+			var header struct {
+				Alg string `json:"alg"`
+				Typ string `json:"typ"`
+				Kid string `json:"kid"`
+			}
+			decodeJWTPart(t, parts[0], &header)
+			if header.Alg != tt.alg || header.Typ != "JWT" || header.Kid != "key-1" {
+				t.Errorf("header = %+v, want alg %s, typ JWT and kid key-1", header, tt.alg)
+			}
+			var claims map[string]string
+			decodeJWTPart(t, parts[1], &claims)
+			if claims["sub"] != "client" {
+				t.Errorf("claims = %v, want sub client", claims)
+			}
+
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.verify(tt.key.Public(), []byte(parts[0]+"."+parts[1]), signature) {
+				t.Error("the signature does not verify")
+			}
+		})
+	}
+}
+
+// verifyECDSA verifies a JWS r||s signature whose halves are size bytes long.
+func verifyECDSA(pub *ecdsa.PublicKey, digest, signature []byte, size int) bool {
+	if len(signature) != 2*size {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+	return ecdsa.Verify(pub, digest, r, s)
+}
+
+func decodeJWTPart(t *testing.T, part string, v interface{}) {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+}
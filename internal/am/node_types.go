@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// NodeType is a journey node type installed on the server, whether it ships
+// with AM or comes from a custom or marketplace node JAR.
+type NodeType struct {
+	ID         string   `json:"_id"`
+	Name       string   `json:"name"`
+	Collection bool     `json:"collection"`
+	Tags       []string `json:"tags"`
+}
+
+// NodeOutcome is a possible outcome of a node.
+type NodeOutcome struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+func nodeTypesPath(realm string) (string, error) {
+	base, err := RealmAPIPath(realm)
+	return base + "/realm-config/authentication/authenticationtrees/nodes", err
+}
+
+// ListNodeTypes returns every node type installed on the server.
+func (c *Client) ListNodeTypes(ctx context.Context, realm string) ([]NodeType, error) {
+	path, err := nodeTypesPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Result []NodeType `json:"result"`
+	}
+	if err := c.Post(ctx, path+"?_action=getAllTypes", JourneyAPIVersion, struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+// GetNodeTypeSchema returns the JSON schema of the configuration of a node type.
+func (c *Client) GetNodeTypeSchema(ctx context.Context, realm, nodeType string) (json.RawMessage, error) {
+	path, err := nodeTypesPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var schema json.RawMessage
+	if err := c.Post(ctx, path+"/"+url.PathEscape(nodeType)+"?_action=schema", JourneyAPIVersion, struct{}{}, &schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// GetNodeTypeTemplate returns the default configuration of a node type.
+func (c *Client) GetNodeTypeTemplate(ctx context.Context, realm, nodeType string) (json.RawMessage, error) {
+	path, err := nodeTypesPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var template json.RawMessage
+	if err := c.Post(ctx, path+"/"+url.PathEscape(nodeType)+"?_action=template", JourneyAPIVersion, struct{}{}, &template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListNodeOutcomes returns the outcomes of a node type for the given
+// configuration. Most node types have fixed outcomes; for others, such as the
+// Choice Collector, they follow from the configuration.
+func (c *Client) ListNodeOutcomes(ctx context.Context, realm, nodeType string, config json.RawMessage) ([]NodeOutcome, error) {
+	if len(config) == 0 {
+		config = json.RawMessage("{}")
+	}
+	path, err := nodeTypesPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var outcomes []NodeOutcome
+	if err := c.Post(ctx, path+"/"+url.PathEscape(nodeType)+"?_action=listOutcomes", JourneyAPIVersion, config, &outcomes); err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// OpenIDConfiguration holds the commonly used members of a realm's OpenID
+// Connect discovery document. Raw keeps the complete document.
+type OpenIDConfiguration struct {
+	Issuer                                 string   `json:"issuer"`
+	AuthorizationEndpoint                  string   `json:"authorization_endpoint"`
+	TokenEndpoint                          string   `json:"token_endpoint"`
+	UserinfoEndpoint                       string   `json:"userinfo_endpoint"`
+	JWKSURI                                string   `json:"jwks_uri"`
+	EndSessionEndpoint                     string   `json:"end_session_endpoint"`
+	IntrospectionEndpoint                  string   `json:"introspection_endpoint"`
+	RevocationEndpoint                     string   `json:"revocation_endpoint"`
+	RegistrationEndpoint                   string   `json:"registration_endpoint"`
+	DeviceAuthorizationEndpoint            string   `json:"device_authorization_endpoint"`
+	ScopesSupported                        []string `json:"scopes_supported"`
+	ResponseTypesSupported                 []string `json:"response_types_supported"`
+	GrantTypesSupported                    []string `json:"grant_types_supported"`
+	SubjectTypesSupported                  []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported       []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported      []string `json:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                        []string `json:"claims_supported"`
+	CodeChallengeMethodsSupported          []string `json:"code_challenge_methods_supported"`
+	RequestObjectSigningAlgValuesSupported []string `json:"request_object_signing_alg_values_supported"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// OAuth2URL returns the URL of p below the OAuth 2.0 endpoints of realm, e.g.
+// https://am.example.com/openam/oauth2/realms/root/realms/alpha/access_token.
+func (c *Client) OAuth2URL(realm, p string) (string, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return "", err
+	}
+	return c.HostURL + "/oauth2" + base + p, nil
+}
+
+// GetOpenIDConfiguration reads the OpenID Connect discovery document of realm.
+func (c *Client) GetOpenIDConfiguration(ctx context.Context, realm string) (*OpenIDConfiguration, error) {
+	u, err := c.OAuth2URL(realm, "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := c.doURL(ctx, http.MethodGet, u, "", nil, nil, &raw, false); err != nil {
+		return nil, err
+	}
+	var config OpenIDConfiguration
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+	config.Raw = raw
+	return &config, nil
+}
+
+// GetJWKS reads the public signing and encryption keys of realm.
+func (c *Client) GetJWKS(ctx context.Context, realm string) (*JWKSet, error) {
+	u, err := c.OAuth2URL(realm, "/connect/jwk_uri")
+	if err != nil {
+		return nil, err
+	}
+	var set JWKSet
+	if err := c.doURL(ctx, http.MethodGet, u, "", nil, nil, &set, false); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
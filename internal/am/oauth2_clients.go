@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// OAuth2ClientAPIVersion is the Accept-API-Version used for OAuth 2.0 client agents.
+const OAuth2ClientAPIVersion = "protocol=2.0,resource=1.0"
+
+// OAuth2Client holds the commonly audited settings of an OAuth 2.0 client
+// agent. AM nests them in configuration sections and wraps most of them in
+// inherited/value objects; Raw keeps the complete object.
+type OAuth2Client struct {
+	ID                      string
+	ClientType              string
+	Status                  string
+	ClientName              string
+	RedirectURIs            []string
+	Scopes                  []string
+	DefaultScopes           []string
+	GrantTypes              []string
+	ResponseTypes           []string
+	TokenEndpointAuthMethod string
+
+	Raw json.RawMessage
+}
+
+// UnmarshalJSON picks the settings out of their configuration sections.
+func (o *OAuth2Client) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID       string                     `json:"_id"`
+		Core     map[string]json.RawMessage `json:"coreOAuth2ClientConfig"`
+		Advanced map[string]json.RawMessage `json:"advancedOAuth2ClientConfig"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var name json.RawMessage
+	*o = OAuth2Client{ID: raw.ID, Raw: append(json.RawMessage(nil), data...)}
+	for _, f := range []struct {
+		section map[string]json.RawMessage
+		key     string
+		out     interface{}
+	}{
+		{raw.Core, "clientType", &o.ClientType},
+		{raw.Core, "status", &o.Status},
+		{raw.Core, "clientName", &name},
+		{raw.Core, "redirectionUris", &o.RedirectURIs},
+		{raw.Core, "scopes", &o.Scopes},
+		{raw.Core, "defaultScopes", &o.DefaultScopes},
+		{raw.Advanced, "grantTypes", &o.GrantTypes},
+		{raw.Advanced, "responseTypes", &o.ResponseTypes},
+		{raw.Advanced, "tokenEndpointAuthMethod", &o.TokenEndpointAuthMethod},
+	} {
+		if err := configValue(f.section, f.key, f.out); err != nil {
+			return err
+		}
+	}
+	if names, ok := stringValues(name); ok {
+		o.ClientName = first(names)
+	}
+	return nil
+}
+
+// configValue decodes section[key] into out, unwrapping an inherited/value
+// object. A missing key leaves out untouched.
+func configValue(section map[string]json.RawMessage, key string, out interface{}) error {
+	raw, ok := section[key]
+	if !ok {
+		return nil
+	}
+	if v, ok := asInheritedValue(raw); ok {
+		raw = v.Value
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// QueryOAuth2Clients returns the OAuth 2.0 client agents of realm that match params.
+func (c *Client) QueryOAuth2Clients(ctx context.Context, realm string, params QueryParams) ([]OAuth2Client, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.Query(ctx, base+"/realm-config/agents/OAuth2Client", OAuth2ClientAPIVersion, params)
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]OAuth2Client, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &clients[i]); err != nil {
+			return nil, err
+		}
+	}
+	return clients, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+)
+
+// PolicyAPIVersion is the Accept-API-Version used for policy evaluation.
+const PolicyAPIVersion = "protocol=1.0,resource=2.0"
+
+// PolicyEvaluationRequest asks which actions a subject may perform on a set
+// of resources under a policy set.
+type PolicyEvaluationRequest struct {
+	Resources   []string            `json:"resources"`
+	Application string              `json:"application"`
+	Subject     PolicySubject       `json:"subject"`
+	Environment map[string][]string `json:"environment,omitempty"`
+}
+
+// PolicySubject identifies whom a policy evaluation is for. Exactly one of
+// its members should be set.
+type PolicySubject struct {
+	SSOToken string                 `json:"ssoToken,omitempty"`
+	JWT      string                 `json:"jwt,omitempty"`
+	Claims   map[string]interface{} `json:"claims,omitempty"`
+}
+
+// PolicyDecision is the outcome of a policy evaluation for one resource.
+type PolicyDecision struct {
+	Resource   string              `json:"resource"`
+	Actions    map[string]bool     `json:"actions"`
+	Attributes map[string][]string `json:"attributes"`
+	Advices    map[string][]string `json:"advices"`
+	TTL        int64               `json:"ttl"`
+}
+
+// EvaluatePolicies evaluates the policies of realm for req.
+func (c *Client) EvaluatePolicies(ctx context.Context, realm string, req PolicyEvaluationRequest) ([]PolicyDecision, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var decisions []PolicyDecision
+	if err := c.Post(ctx, base+"/policies?_action=evaluate", PolicyAPIVersion, req, &decisions); err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
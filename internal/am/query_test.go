@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient returns a client for a server that signs every user in and
+// passes the remaining requests to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/am/json/realms/root/authenticate":
+			fmt.Fprint(w, `{"tokenId": "token"}`)
+		case "/am/json/serverinfo/*":
+			fmt.Fprint(w, `{"cookieName": "iPlanetDirectoryPro"}`)
+		default:
+			handler(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	host := server.URL + "/am"
+	c, err := NewClient(&host, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestQueryConfigCollection(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"result": [{"_id": "b", "enabled": true}, {"_id": "a", "enabled": true}, {"_id": "c", "enabled": false}], "resultCount": 3}`)
+	})
+
+	journeys, err := c.QueryJourneys(context.Background(), "/alpha", QueryParams{Filter: "enabled eq true", SortKeys: []string{"_id"}, PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(journeys) != 2 || journeys[0].ID != "a" || journeys[1].ID != "b" {
+		t.Errorf("QueryJourneys = %+v, want journeys a and b", journeys)
+	}
+	if len(queries) != 1 || queries[0] != "_queryFilter=true" {
+		t.Errorf("queries = %q, want one _queryFilter=true query", queries)
+	}
+}
+
+func TestQueryPages(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("_pagedResultsCookie") == "" {
+			fmt.Fprint(w, `{"result": [{"_id": "a"}], "pagedResultsCookie": "next"}`)
+			return
+		}
+		fmt.Fprint(w, `{"result": [{"_id": "b"}]}`)
+	})
+
+	results, err := c.Query(context.Background(), "/realms/root/realms/alpha/scripts", ServiceAPIVersion, QueryParams{Filter: `name sw "x"`, PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Query returned %d results, want 2", len(results))
+	}
+	want := []string{
+		"_pageSize=1&_queryFilter=name+sw+%22x%22",
+		"_pageSize=1&_pagedResultsCookie=next&_queryFilter=name+sw+%22x%22",
+	}
+	if fmt.Sprint(queries) != fmt.Sprint(want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
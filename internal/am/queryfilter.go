@@ -0,0 +1,462 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// queryFilter is a parsed CREST _queryFilter expression. It is evaluated by
+// the provider for the configuration collections AM can only list in full.
+type queryFilter struct {
+	// op is true, false, and, or, !, pr, eq, co, sw, lt, le, gt or ge.
+	op       string
+	operands []*queryFilter
+	pointer  []string
+	value    interface{}
+}
+
+var comparisonOperators = map[string]bool{"eq": true, "co": true, "sw": true, "lt": true, "le": true, "gt": true, "ge": true}
+
+// parseQueryFilter parses a CREST query filter such as
+// `enabled eq false and !(description co "test")`. An empty filter matches
+// everything.
+func parseQueryFilter(s string) (*queryFilter, error) {
+	if strings.TrimSpace(s) == "" {
+		return &queryFilter{op: "true"}, nil
+	}
+	tokens, err := tokenizeQueryFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); ok {
+		return nil, fmt.Errorf("invalid query filter %q: unexpected %q", s, t.text)
+	}
+	return f, nil
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeQueryFilter(s string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == '!':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(s) && s[end] != c {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("invalid query filter %q: unterminated string", s)
+			}
+			text := s[i+1 : end]
+			if c == '"' {
+				var err error
+				if text, err = strconv.Unquote(s[i : end+1]); err != nil {
+					return nil, fmt.Errorf("invalid query filter %q: %w", s, err)
+				}
+			}
+			tokens = append(tokens, filterToken{text: text, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(s) && !strings.ContainsRune(" \t\n()", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, filterToken{text: s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	t, ok := p.peek()
+	if !ok {
+		return t, fmt.Errorf("invalid query filter: unexpected end")
+	}
+	p.pos++
+	return t, nil
+}
+
+// keyword reports whether the next token is the unquoted word w, and
+// consumes it if so.
+func (p *filterParser) keyword(w string) bool {
+	t, ok := p.peek()
+	if ok && !t.quoted && strings.EqualFold(t.text, w) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) or() (*queryFilter, error) {
+	return p.binary("or", p.and)
+}
+
+func (p *filterParser) and() (*queryFilter, error) {
+	return p.binary("and", p.not)
+}
+
+func (p *filterParser) binary(op string, operand func() (*queryFilter, error)) (*queryFilter, error) {
+	f, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(op) {
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		f = &queryFilter{op: op, operands: []*queryFilter{f, right}}
+	}
+	return f, nil
+}
+
+func (p *filterParser) not() (*queryFilter, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t.quoted:
+		return nil, fmt.Errorf("invalid query filter: expected a field, got %q", t.text)
+	case t.text == "!":
+		f, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &queryFilter{op: "!", operands: []*queryFilter{f}}, nil
+	case t.text == "(":
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t, err := p.next(); err != nil || t.text != ")" || t.quoted {
+			return nil, fmt.Errorf("invalid query filter: expected )")
+		}
+		return f, nil
+	case t.text == "true" || t.text == "false":
+		return &queryFilter{op: t.text}, nil
+	}
+
+	f := &queryFilter{pointer: splitPointer(t.text)}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	f.op = strings.ToLower(op.text)
+	if op.quoted || f.op != "pr" && !comparisonOperators[f.op] {
+		return nil, fmt.Errorf("invalid query filter: unknown operator %q", op.text)
+	}
+	if f.op == "pr" {
+		return f, nil
+	}
+
+	v, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case v.quoted:
+		f.value = v.text
+	case v.text == "true" || v.text == "false":
+		f.value = v.text == "true"
+	case v.text == "null":
+		f.value = nil
+	default:
+		n, err := strconv.ParseFloat(v.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query filter: %q is not a string, number, boolean or null", v.text)
+		}
+		f.value = n
+	}
+	return f, nil
+}
+
+// splitPointer splits a JSON pointer such as /coreOAuth2ClientConfig/status
+// into its reference tokens. The leading slash is optional, as in CREST.
+func splitPointer(s string) []string {
+	parts := strings.Split(strings.TrimPrefix(s, "/"), "/")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	return parts
+}
+
+// resolvePointer returns the value at pointer in v, reading through the
+// {"inherited": ..., "value": ...} wrappers of agent attributes.
+func resolvePointer(v interface{}, pointer []string) (interface{}, bool) {
+	for _, token := range pointer {
+		obj, ok := unwrapInherited(v).(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[token]; !ok {
+			return nil, false
+		}
+	}
+	return unwrapInherited(v), true
+}
+
+func unwrapInherited(v interface{}) interface{} {
+	if obj, ok := v.(map[string]interface{}); ok && len(obj) == 2 {
+		if _, ok := obj["inherited"].(bool); ok {
+			if value, ok := obj["value"]; ok {
+				return value
+			}
+		}
+	}
+	return v
+}
+
+// matches evaluates the filter against a decoded JSON object. A field holding
+// an array matches when one of its elements does. co and sw ignore case.
+func (f *queryFilter) matches(obj interface{}) bool {
+	switch f.op {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "and":
+		return f.operands[0].matches(obj) && f.operands[1].matches(obj)
+	case "or":
+		return f.operands[0].matches(obj) || f.operands[1].matches(obj)
+	case "!":
+		return !f.operands[0].matches(obj)
+	}
+
+	v, ok := resolvePointer(obj, f.pointer)
+	if f.op == "pr" {
+		return ok && v != nil
+	}
+	if !ok || v == nil {
+		return f.op == "eq" && f.value == nil
+	}
+	candidates := []interface{}{v}
+	if a, isArray := v.([]interface{}); isArray {
+		candidates = a
+	}
+	for _, c := range candidates {
+		if f.compare(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *queryFilter) compare(v interface{}) bool {
+	switch f.op {
+	case "eq":
+		return compareJSON(v, f.value) == 0
+	case "co", "sw":
+		s, ok := v.(string)
+		want, isString := f.value.(string)
+		if !ok || !isString {
+			return false
+		}
+		s, want = strings.ToLower(s), strings.ToLower(want)
+		if f.op == "co" {
+			return strings.Contains(s, want)
+		}
+		return strings.HasPrefix(s, want)
+	}
+
+	c := compareJSON(v, f.value)
+	switch {
+	case c == incomparable:
+		return false
+	case f.op == "lt":
+		return c < 0
+	case f.op == "le":
+		return c <= 0
+	case f.op == "gt":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// incomparable is returned by compareJSON for values of different types.
+const incomparable = 2
+
+// compareJSON orders two decoded JSON scalars of the same type, returning
+// -1, 0 or 1, or incomparable.
+func compareJSON(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0
+			case !a:
+				return -1
+			}
+			return 1
+		}
+	case nil:
+		if b == nil {
+			return 0
+		}
+	}
+	return incomparable
+}
+
+// applyQuery filters, sorts, limits and projects the results of a collection
+// that only supports _queryFilter=true, as the server would for params.
+func applyQuery(results []json.RawMessage, params QueryParams) ([]json.RawMessage, error) {
+	filter, err := parseQueryFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		raw     json.RawMessage
+		decoded interface{}
+	}
+	var matched []entry
+	for _, raw := range results {
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, err
+		}
+		if filter.matches(decoded) {
+			matched = append(matched, entry{raw, decoded})
+		}
+	}
+
+	for i := len(params.SortKeys) - 1; i >= 0; i-- {
+		key := params.SortKeys[i]
+		descending := strings.HasPrefix(key, "-")
+		pointer := splitPointer(strings.TrimLeft(key, "+-"))
+		sort.SliceStable(matched, func(a, b int) bool {
+			c := compareSortValues(matched[a].decoded, matched[b].decoded, pointer)
+			if descending {
+				return c > 0
+			}
+			return c < 0
+		})
+	}
+
+	if params.Limit > 0 && len(matched) > params.Limit {
+		matched = matched[:params.Limit]
+	}
+
+	out := make([]json.RawMessage, len(matched))
+	for i, e := range matched {
+		out[i] = e.raw
+		if len(params.Fields) == 0 {
+			continue
+		}
+		if out[i], err = json.Marshal(projectFields(e.decoded, params.Fields)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// compareSortValues orders a and b by the value at pointer. Objects without
+// the value sort first, as do values that cannot be compared.
+func compareSortValues(a, b interface{}, pointer []string) int {
+	va, okA := resolvePointer(a, pointer)
+	vb, okB := resolvePointer(b, pointer)
+	okA, okB = okA && va != nil, okB && vb != nil
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	if c := compareJSON(va, vb); c != incomparable {
+		return c
+	}
+	return 0
+}
+
+// projectFields keeps the fields of v named by the JSON pointers fields,
+// as _fields does.
+func projectFields(v interface{}, fields []string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	for _, field := range fields {
+		pointer := splitPointer(field)
+		value, ok := resolveRaw(v, pointer)
+		if !ok {
+			continue
+		}
+		target := projected
+		for _, token := range pointer[:len(pointer)-1] {
+			next, ok := target[token].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				target[token] = next
+			}
+			target = next
+		}
+		target[pointer[len(pointer)-1]] = value
+	}
+	return projected
+}
+
+// resolveRaw returns the value at pointer without unwrapping it, so that
+// projected objects keep the shape AM returns.
+func resolveRaw(v interface{}, pointer []string) (interface{}, bool) {
+	for _, token := range pointer {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[token]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testJourney = `{
+	"_id": "Login",
+	"description": "Default Login",
+	"enabled": true,
+	"identityResource": "managed/alpha_user",
+	"nodes": {"a": {}, "b": {}},
+	"tags": ["default", "login"],
+	"coreOAuth2ClientConfig": {"status": {"inherited": false, "value": "Active"}}
+}`
+
+func TestQueryFilterMatches(t *testing.T) {
+	var journey interface{}
+	if err := json.Unmarshal([]byte(testJourney), &journey); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"true", true},
+		{"false", false},
+		{`_id eq "Login"`, true},
+		{`/_id eq "Login"`, true},
+		{`_id eq "login"`, false},
+		{`_id co "OGI"`, true},
+		{`description sw "default"`, true},
+		{`description sw "Login"`, false},
+		{"enabled eq true", true},
+		{"enabled eq false", false},
+		{"missing eq null", true},
+		{"description pr", true},
+		{"missing pr", false},
+		{`tags eq "login"`, true},
+		{`tags eq "other"`, false},
+		{`coreOAuth2ClientConfig/status eq "Active"`, true},
+		{`_id gt "A" and _id lt "M"`, true},
+		{`_id ge "Login" and _id le "Login"`, true},
+		{`_id gt "Login"`, false},
+		{`enabled eq false or _id eq "Login"`, true},
+		{`!(enabled eq true)`, false},
+		{`! enabled eq false`, true},
+		{`enabled eq false or (_id eq "Login" and !(description co "test"))`, true},
+		{`description eq 'Default Login'`, true},
+		{`description eq "Default \"Login\""`, false},
+		{"enabled gt 1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := parseQueryFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseQueryFilter: %s", err)
+			}
+			if got := f.matches(journey); got != tt.want {
+				t.Errorf("matches = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryFilterErrors(t *testing.T) {
+	for _, filter := range []string{
+		`_id`,
+		`_id eq`,
+		`_id is "Login"`,
+		`_id eq Login`,
+		`(_id eq "Login"`,
+		`_id eq "Login")`,
+		`_id eq "Login`,
+		`"_id" eq "Login"`,
+		`_id eq "Login" and`,
+	} {
+		if _, err := parseQueryFilter(filter); err == nil {
+			t.Errorf("parseQueryFilter(%q) succeeded, want an error", filter)
+		}
+	}
+}
+
+func TestApplyQuery(t *testing.T) {
+	results := []json.RawMessage{
+		json.RawMessage(`{"_id": "b", "enabled": true, "order": 2, "config": {"name": "B", "secret": "x"}}`),
+		json.RawMessage(`{"_id": "a", "enabled": false, "order": 3, "config": {"name": "A", "secret": "y"}}`),
+		json.RawMessage(`{"_id": "c", "enabled": true, "config": {"name": "C", "secret": "z"}}`),
+		json.RawMessage(`{"_id": "d", "enabled": true, "order": 1, "config": {"name": "D", "secret": "w"}}`),
+	}
+
+	tests := []struct {
+		name   string
+		params QueryParams
+		want   []string
+	}{
+		{"everything", QueryParams{}, []string{
+			`{"_id": "b", "enabled": true, "order": 2, "config": {"name": "B", "secret": "x"}}`,
+			`{"_id": "a", "enabled": false, "order": 3, "config": {"name": "A", "secret": "y"}}`,
+			`{"_id": "c", "enabled": true, "config": {"name": "C", "secret": "z"}}`,
+			`{"_id": "d", "enabled": true, "order": 1, "config": {"name": "D", "secret": "w"}}`,
+		}},
+		{"filter and sort", QueryParams{Filter: "enabled eq true", SortKeys: []string{"_id"}, Fields: []string{"_id"}},
+			[]string{`{"_id":"b"}`, `{"_id":"c"}`, `{"_id":"d"}`}},
+		{"descending", QueryParams{SortKeys: []string{"-_id"}, Fields: []string{"_id"}},
+			[]string{`{"_id":"d"}`, `{"_id":"c"}`, `{"_id":"b"}`, `{"_id":"a"}`}},
+		{"missing values first", QueryParams{SortKeys: []string{"+order"}, Fields: []string{"_id"}},
+			[]string{`{"_id":"c"}`, `{"_id":"d"}`, `{"_id":"b"}`, `{"_id":"a"}`}},
+		{"secondary sort key", QueryParams{SortKeys: []string{"enabled", "-_id"}, Fields: []string{"_id"}},
+			[]string{`{"_id":"a"}`, `{"_id":"d"}`, `{"_id":"c"}`, `{"_id":"b"}`}},
+		{"limit", QueryParams{SortKeys: []string{"_id"}, Limit: 2, Fields: []string{"_id"}},
+			[]string{`{"_id":"a"}`, `{"_id":"b"}`}},
+		{"nested fields", QueryParams{Filter: `config/name eq "A"`, Fields: []string{"_id", "config/name"}},
+			[]string{`{"_id":"a","config":{"name":"A"}}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyQuery(results, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotStrings := make([]string, len(got))
+			for i, r := range got {
+				gotStrings[i] = string(r)
+			}
+			if !reflect.DeepEqual(gotStrings, tt.want) {
+				t.Errorf("applyQuery =\n%q\nwant\n%q", gotStrings, tt.want)
+			}
+		})
+	}
+}
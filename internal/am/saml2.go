@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SAML2Metadata is the exported metadata of a hosted or remote SAML entity.
+// Raw keeps the XML exactly as AM returned it.
+type SAML2Metadata struct {
+	EntityID string               `xml:"entityID,attr"`
+	IDP      *SAML2RoleDescriptor `xml:"IDPSSODescriptor"`
+	SP       *SAML2RoleDescriptor `xml:"SPSSODescriptor"`
+
+	Raw []byte `xml:"-"`
+}
+
+// SAML2RoleDescriptor holds the keys and endpoints of one role of an entity.
+type SAML2RoleDescriptor struct {
+	KeyDescriptors            []SAML2KeyDescriptor `xml:"KeyDescriptor"`
+	SingleSignOnServices      []SAML2Endpoint      `xml:"SingleSignOnService"`
+	SingleLogoutServices      []SAML2Endpoint      `xml:"SingleLogoutService"`
+	AssertionConsumerServices []SAML2Endpoint      `xml:"AssertionConsumerService"`
+}
+
+// SAML2KeyDescriptor is a certificate published for signing, encryption or,
+// when Use is empty, both.
+type SAML2KeyDescriptor struct {
+	Use         string `xml:"use,attr"`
+	Certificate string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+// SAML2Endpoint is a protocol endpoint of an entity.
+type SAML2Endpoint struct {
+	Binding          string `xml:"Binding,attr"`
+	Location         string `xml:"Location,attr"`
+	ResponseLocation string `xml:"ResponseLocation,attr"`
+	Index            string `xml:"index,attr"`
+}
+
+// ParseCertificate decodes the certificate of the key descriptor.
+func (k SAML2KeyDescriptor) ParseCertificate() (*x509.Certificate, error) {
+	// Metadata usually wraps the base64 text over several lines.
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(k.Certificate), ""))
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// GetSAML2Metadata exports the metadata of entityID in realm.
+func (c *Client) GetSAML2Metadata(ctx context.Context, realm, entityID string) (*SAML2Metadata, error) {
+	name, err := CanonicalRealm(realm)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	q.Set("entityid", entityID)
+	q.Set("realm", name)
+
+	body, err := c.getRaw(ctx, c.HostURL+"/saml2/jsp/exportmetadata.jsp?"+q.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	// The JSP answers 200 with an HTML page when the entity does not exist.
+	if !bytes.Contains(body, []byte("EntityDescriptor")) {
+		return nil, &Error{StatusCode: http.StatusNotFound, Body: body}
+	}
+
+	var metadata SAML2Metadata
+	if err := xml.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("unable to parse metadata of %q: %w", entityID, err)
+	}
+	metadata.Raw = body
+	return &metadata, nil
+}
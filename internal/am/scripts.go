@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Script is a script of the realm's scripting service. The source is base64
+// encoded on the wire; use Source to read it.
+type Script struct {
+	ID               string `json:"_id"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	Language         string `json:"language"`
+	Context          string `json:"context"`
+	Script           string `json:"script"`
+	Default          bool   `json:"default"`
+	EvaluatorVersion string `json:"evaluatorVersion,omitempty"`
+	CreatedBy        string `json:"createdBy,omitempty"`
+	CreationDate     int64  `json:"creationDate,omitempty"`
+	LastModifiedBy   string `json:"lastModifiedBy,omitempty"`
+	LastModifiedDate int64  `json:"lastModifiedDate,omitempty"`
+}
+
+// Source returns the decoded script source.
+func (s Script) Source() (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s.Script)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// QueryScripts returns the scripts of realm that match params.
+func (c *Client) QueryScripts(ctx context.Context, realm string, params QueryParams) ([]Script, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.Query(ctx, base+"/scripts", ServiceAPIVersion, params)
+	if err != nil {
+		return nil, err
+	}
+	scripts := make([]Script, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &scripts[i]); err != nil {
+			return nil, err
+		}
+	}
+	return scripts, nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ServerInfo is the public server information of a realm as returned by
+// /json/serverinfo/*. It is readable without a session.
+type ServerInfo struct {
+	CookieName                            string                 `json:"cookieName"`
+	SecureCookie                          bool                   `json:"secureCookie"`
+	Domains                               []string               `json:"domains"`
+	Realm                                 string                 `json:"realm"`
+	Lang                                  string                 `json:"lang"`
+	ProtectedUserAttributes               []string               `json:"protectedUserAttributes"`
+	UserIDAttributes                      []string               `json:"userIdAttributes"`
+	ForgotPassword                        StringBool             `json:"forgotPassword"`
+	ForgotUsername                        StringBool             `json:"forgotUsername"`
+	KBAEnabled                            StringBool             `json:"kbaEnabled"`
+	SelfRegistration                      StringBool             `json:"selfRegistration"`
+	ReferralsEnabled                      StringBool             `json:"referralsEnabled"`
+	SuccessfulUserRegistrationDestination string                 `json:"successfulUserRegistrationDestination"`
+	SocialImplementations                 []SocialImplementation `json:"socialImplementations"`
+	ZeroPageLogin                         ZeroPageLogin          `json:"zeroPageLogin"`
+	XUIUserSessionValidationEnabled       bool                   `json:"xuiUserSessionValidationEnabled"`
+	FileBasedConfiguration                bool                   `json:"fileBasedConfiguration"`
+}
+
+// SocialImplementation is a social identity provider offered on the login page.
+type SocialImplementation struct {
+	DisplayName string `json:"displayName"`
+	AuthnChain  string `json:"authnChain"`
+	IconPath    string `json:"iconPath"`
+	Valid       bool   `json:"valid"`
+}
+
+// ZeroPageLogin describes whether credentials may be passed as request headers
+// or parameters.
+type ZeroPageLogin struct {
+	Enabled               bool     `json:"enabled"`
+	RefererWhitelist      []string `json:"refererWhitelist"`
+	AllowedWithoutReferer bool     `json:"allowedWithoutReferer"`
+}
+
+// StringBool decodes the flags serverinfo reports as either JSON booleans or
+// the strings "true" and "false", depending on the AM version.
+type StringBool bool
+
+func (b *StringBool) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*b = false
+		return nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	*b = StringBool(v)
+	return nil
+}
+
+// ServerVersion is the response of /json/serverinfo/version.
+type ServerVersion struct {
+	Version     string `json:"version"`
+	FullVersion string `json:"fullVersion"`
+	Revision    string `json:"revision"`
+	Date        string `json:"date"`
+}
+
+// GetServerInfo reads the public server information of realm.
+func (c *Client) GetServerInfo(ctx context.Context, realm string) (*ServerInfo, error) {
+	base, err := RealmAPIPath(realm)
+	if err != nil {
+		return nil, err
+	}
+	var info ServerInfo
+	if err := c.do(ctx, http.MethodGet, base+"/serverinfo/*", ServiceAPIVersion, nil, nil, &info, false); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetServerVersion reads the version of the server.
+func (c *Client) GetServerVersion(ctx context.Context) (*ServerVersion, error) {
+	var v ServerVersion
+	if err := c.Get(ctx, "/serverinfo/version", ServiceAPIVersion, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Version returns the parsed version of the server. It is read once and
+// cached for the lifetime of the client.
+func (c *Client) Version(ctx context.Context) (Version, error) {
+	c.mu.Lock()
+	cached := c.version
+	c.mu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	sv, err := c.GetServerVersion(ctx)
+	if err != nil {
+		return Version{}, err
+	}
+	v, err := ParseVersion(sv.Version)
+	if err != nil {
+		return Version{}, err
+	}
+
+	c.mu.Lock()
+	c.version = &v
+	c.mu.Unlock()
+	return v, nil
+}
+
+// identityCloudHosts are the host name patterns of Identity Cloud tenants.
+// A * stands for a single DNS label.
+var identityCloudHosts = []string{"*.forgeblocks.com", "openam-*.id.forgerock.io"}
+
+// IsIdentityCloud reports whether the client talks to an Identity Cloud
+// tenant. Tenants report the AM release they run on like any other server,
+// so they are recognised by their host name.
+func (c *Client) IsIdentityCloud() bool {
+	u, err := url.Parse(c.HostURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, pattern := range identityCloudHosts {
+		prefix, suffix, _ := strings.Cut(pattern, "*")
+		if len(host) <= len(prefix)+len(suffix) || !strings.HasPrefix(host, prefix) || !strings.HasSuffix(host, suffix) {
+			continue
+		}
+		if label := host[len(prefix) : len(host)-len(suffix)]; !strings.Contains(label, ".") {
+			return true
+		}
+	}
+	return false
+}
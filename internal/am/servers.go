@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ServerDefaultID is the pseudo server holding the server-default properties.
+const ServerDefaultID = "server-default"
+
+// Site is a site of a self-managed AM deployment.
+type Site struct {
+	ID            string       `json:"_id"`
+	URL           string       `json:"url"`
+	SecondaryURLs []string     `json:"secondaryURLs"`
+	Servers       []SiteServer `json:"servers,omitempty"`
+}
+
+// SiteServer is a member of a site.
+type SiteServer struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Server is an AM server instance of a self-managed deployment.
+type Server struct {
+	ID       string `json:"_id,omitempty"`
+	URL      string `json:"url"`
+	SiteName string `json:"siteName"`
+}
+
+// GetSite reads a site.
+func (c *Client) GetSite(ctx context.Context, id string) (*Site, error) {
+	var s Site
+	if err := c.Get(ctx, "/global-config/sites/"+url.PathEscape(id), ServiceAPIVersion, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// CreateSite creates a site named s.ID.
+func (c *Client) CreateSite(ctx context.Context, s Site) (*Site, error) {
+	var result Site
+	if err := c.Post(ctx, "/global-config/sites?_action=create", ServiceAPIVersion, s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateSite replaces the URLs of a site.
+func (c *Client) UpdateSite(ctx context.Context, s Site) (*Site, error) {
+	var result Site
+	if err := c.Put(ctx, "/global-config/sites/"+url.PathEscape(s.ID), ServiceAPIVersion, s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSite deletes a site.
+func (c *Client) DeleteSite(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/global-config/sites/"+url.PathEscape(id), ServiceAPIVersion, nil)
+}
+
+// GetServer reads a server.
+func (c *Client) GetServer(ctx context.Context, id string) (*Server, error) {
+	var s Server
+	if err := c.Get(ctx, "/global-config/servers/"+url.PathEscape(id), ServiceAPIVersion, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// CreateServer registers a server. AM assigns the server ID.
+func (c *Client) CreateServer(ctx context.Context, s Server) (*Server, error) {
+	var result Server
+	if err := c.Post(ctx, "/global-config/servers?_action=create", ServiceAPIVersion, s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateServer changes the site membership of a server.
+func (c *Client) UpdateServer(ctx context.Context, s Server) (*Server, error) {
+	var result Server
+	if err := c.Put(ctx, "/global-config/servers/"+url.PathEscape(s.ID), ServiceAPIVersion, s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteServer removes a server from the configuration.
+func (c *Client) DeleteServer(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/global-config/servers/"+url.PathEscape(id), ServiceAPIVersion, nil)
+}
+
+// PropertyTab is one tab of server properties, such as general or advanced,
+// exactly as AM returns it. Most tabs group properties into sections; the
+// advanced tab is a flat map. Per-server values are wrapped in an object that
+// records whether the value is inherited from the server defaults.
+type PropertyTab map[string]json.RawMessage
+
+// ServerProperty is a single property of a PropertyTab.
+type ServerProperty struct {
+	Section   string
+	Value     json.RawMessage
+	Inherited bool
+}
+
+type inheritedValue struct {
+	Inherited bool            `json:"inherited"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// GetServerProperties reads a property tab of a server, or of the server
+// defaults when serverID is ServerDefaultID.
+func (c *Client) GetServerProperties(ctx context.Context, serverID, tab string) (PropertyTab, error) {
+	var t PropertyTab
+	if err := c.Get(ctx, serverPropertiesPath(serverID, tab), ServiceAPIVersion, &t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateServerProperties writes a property tab of a server.
+func (c *Client) UpdateServerProperties(ctx context.Context, serverID, tab string, t PropertyTab) (PropertyTab, error) {
+	var result PropertyTab
+	if err := c.Put(ctx, serverPropertiesPath(serverID, tab), ServiceAPIVersion, t, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func serverPropertiesPath(serverID, tab string) string {
+	return "/global-config/servers/" + url.PathEscape(serverID) + "/properties/" + url.PathEscape(tab)
+}
+
+// Properties flattens the tab into a map keyed by property name.
+func (t PropertyTab) Properties() map[string]ServerProperty {
+	props := map[string]ServerProperty{}
+	for key, raw := range t {
+		if len(key) > 0 && key[0] == '_' {
+			continue
+		}
+		if v, ok := asInheritedValue(raw); ok {
+			props[key] = ServerProperty{Value: v.Value, Inherited: v.Inherited}
+			continue
+		}
+		section, ok := asObject(raw)
+		if !ok {
+			props[key] = ServerProperty{Value: raw}
+			continue
+		}
+		for name, value := range section {
+			p := ServerProperty{Section: key, Value: value}
+			if v, ok := asInheritedValue(value); ok {
+				p.Value, p.Inherited = v.Value, v.Inherited
+			}
+			props[name] = p
+		}
+	}
+	return props
+}
+
+// Set overrides a property. Properties that do not exist yet are only
+// accepted on flat tabs such as advanced.
+func (t PropertyTab) Set(key string, value json.RawMessage) error {
+	raw, section, found := t.lookup(key)
+	if !found && t.hasSections() {
+		return fmt.Errorf("unknown property %q", key)
+	}
+	if _, wrapped := asInheritedValue(raw); wrapped {
+		value = mustMarshal(inheritedValue{Inherited: false, Value: value})
+	}
+	return t.replace(section, key, value)
+}
+
+// Inherit makes a per-server property fall back to the server default.
+// Flat properties without inheritance are removed instead.
+func (t PropertyTab) Inherit(key string) error {
+	raw, section, found := t.lookup(key)
+	if !found {
+		return nil
+	}
+	v, wrapped := asInheritedValue(raw)
+	if !wrapped {
+		if section == "" {
+			delete(t, key)
+		}
+		return nil
+	}
+	v.Inherited = true
+	return t.replace(section, key, mustMarshal(v))
+}
+
+// lookup finds a property and the section it belongs to, which is empty for
+// properties at the top level of the tab.
+func (t PropertyTab) lookup(key string) (json.RawMessage, string, bool) {
+	if raw, ok := t[key]; ok {
+		return raw, "", true
+	}
+	for name, raw := range t {
+		if _, ok := asInheritedValue(raw); ok {
+			continue
+		}
+		if obj, ok := asObject(raw); ok {
+			if value, ok := obj[key]; ok {
+				return value, name, true
+			}
+		}
+	}
+	return nil, "", false
+}
+
+func (t PropertyTab) replace(section, key string, value json.RawMessage) error {
+	if section == "" {
+		t[key] = value
+		return nil
+	}
+	obj, _ := asObject(t[section])
+	obj[key] = value
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	t[section] = raw
+	return nil
+}
+
+func (t PropertyTab) hasSections() bool {
+	for name, raw := range t {
+		if len(name) > 0 && name[0] == '_' {
+			continue
+		}
+		if _, ok := asInheritedValue(raw); ok {
+			continue
+		}
+		if _, ok := asObject(raw); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func asObject(raw json.RawMessage) (map[string]json.RawMessage, bool) {
+	if len(bytes.TrimSpace(raw)) == 0 || bytes.TrimSpace(raw)[0] != '{' {
+		return nil, false
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+func asInheritedValue(raw json.RawMessage) (inheritedValue, bool) {
+	obj, ok := asObject(raw)
+	if !ok || len(obj) != 2 {
+		return inheritedValue{}, false
+	}
+	if _, ok := obj["inherited"]; !ok {
+		return inheritedValue{}, false
+	}
+	var v inheritedValue
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return inheritedValue{}, false
+	}
+	return v, true
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
+
+// PropertyString renders a property value as a string: JSON strings are
+// unquoted, everything else is kept as compact JSON.
+func PropertyString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// PropertyValue is the inverse of PropertyString. The current value decides
+// whether s is sent as a JSON string or as a JSON literal.
+func PropertyValue(s string, current json.RawMessage) json.RawMessage {
+	var currentString string
+	if current == nil || json.Unmarshal(current, &currentString) == nil || !json.Valid([]byte(s)) {
+		return mustMarshal(s)
+	}
+	return json.RawMessage(s)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// SessionService is the realm-level Session service.
+type SessionService struct {
+	Dynamic SessionDynamic `json:"dynamic"`
+}
+
+// SessionDynamic holds the dynamic attributes of the Session service. All
+// times are in minutes.
+type SessionDynamic struct {
+	MaxSessionTime int64 `json:"maxSessionTime"`
+	MaxIdleTime    int64 `json:"maxIdleTime"`
+	MaxCachingTime int64 `json:"maxCachingTime"`
+	QuotaLimit     int64 `json:"quotaLimit"`
+}
+
+// SessionPropertyWhitelist is the realm-level Session Property Whitelist service.
+type SessionPropertyWhitelist struct {
+	SessionPropertyWhitelist   []string `json:"sessionPropertyWhitelist"`
+	WhitelistedQueryProperties []string `json:"whitelistedQueryProperties"`
+}
+
+// CorsService holds the settings of the global CORS service itself. The
+// origins, methods and headers live in its named configurations.
+type CorsService struct {
+	Enabled bool `json:"enabled"`
+}
+
+// CorsConfiguration is a named configuration of the global CORS service.
+// AM 7 applies the configuration whose origins match a request, provided the
+// configuration and the service are both enabled.
+type CorsConfiguration struct {
+	ID               string   `json:"_id"`
+	Enabled          bool     `json:"enabled"`
+	AcceptedOrigins  []string `json:"acceptedOrigins"`
+	AcceptedMethods  []string `json:"acceptedMethods"`
+	AcceptedHeaders  []string `json:"acceptedHeaders"`
+	ExposedHeaders   []string `json:"exposedHeaders"`
+	MaxAge           int64    `json:"maxAge"`
+	AllowCredentials bool     `json:"allowCredentials"`
+}
+
+// ValidationService is the realm-level Validation service.
+type ValidationService struct {
+	ValidGotoDestinations []string `json:"validGotoDestinations"`
+}
+
+const (
+	sessionServicePath           = "/realm-config/services/session"
+	sessionPropertyWhitelistPath = "/realm-config/services/amSessionPropertyWhitelist"
+	corsServicePath              = "/global-config/services/CorsService"
+	validationServicePath        = "/realm-config/services/validation"
+)
+
+func corsConfigurationPath(name string) string {
+	return corsServicePath + "/configuration/" + url.PathEscape(name)
+}
+
+// GetSessionService reads the Session service of the client's realm.
+func (c *Client) GetSessionService(ctx context.Context) (*SessionService, error) {
+	var s SessionService
+	if err := c.Get(ctx, c.RealmPath(sessionServicePath), ServiceAPIVersion, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateSessionService writes the Session service, creating it if required.
+func (c *Client) UpdateSessionService(ctx context.Context, s SessionService) (*SessionService, error) {
+	var result SessionService
+	if err := c.putService(ctx, c.RealmPath(sessionServicePath), s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSessionService removes the realm's Session service so that the
+// global defaults apply again.
+func (c *Client) DeleteSessionService(ctx context.Context) error {
+	return c.deleteService(ctx, c.RealmPath(sessionServicePath))
+}
+
+// GetSessionPropertyWhitelist reads the Session Property Whitelist service.
+func (c *Client) GetSessionPropertyWhitelist(ctx context.Context) (*SessionPropertyWhitelist, error) {
+	var s SessionPropertyWhitelist
+	if err := c.Get(ctx, c.RealmPath(sessionPropertyWhitelistPath), ServiceAPIVersion, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateSessionPropertyWhitelist writes the Session Property Whitelist
+// service, creating it if required.
+func (c *Client) UpdateSessionPropertyWhitelist(ctx context.Context, s SessionPropertyWhitelist) (*SessionPropertyWhitelist, error) {
+	var result SessionPropertyWhitelist
+	if err := c.putService(ctx, c.RealmPath(sessionPropertyWhitelistPath), s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSessionPropertyWhitelist removes the Session Property Whitelist service.
+func (c *Client) DeleteSessionPropertyWhitelist(ctx context.Context) error {
+	return c.deleteService(ctx, c.RealmPath(sessionPropertyWhitelistPath))
+}
+
+// GetCorsService reads the global CORS service.
+func (c *Client) GetCorsService(ctx context.Context) (*CorsService, error) {
+	var s CorsService
+	if err := c.Get(ctx, corsServicePath, ServiceAPIVersion, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetCorsConfiguration reads a configuration of the global CORS service.
+func (c *Client) GetCorsConfiguration(ctx context.Context, name string) (*CorsConfiguration, error) {
+	var cfg CorsConfiguration
+	if err := c.Get(ctx, corsConfigurationPath(name), ServiceAPIVersion, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// PutCorsConfiguration creates or replaces a configuration of the global
+// CORS service.
+func (c *Client) PutCorsConfiguration(ctx context.Context, cfg CorsConfiguration) (*CorsConfiguration, error) {
+	var result CorsConfiguration
+	if err := c.Put(ctx, corsConfigurationPath(cfg.ID), ServiceAPIVersion, cfg, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteCorsConfiguration deletes a configuration of the global CORS service.
+func (c *Client) DeleteCorsConfiguration(ctx context.Context, name string) error {
+	return c.Delete(ctx, corsConfigurationPath(name), ServiceAPIVersion, nil)
+}
+
+// ListCorsConfigurations returns every configuration of the global CORS
+// service.
+func (c *Client) ListCorsConfigurations(ctx context.Context) ([]CorsConfiguration, error) {
+	results, err := c.Query(ctx, corsServicePath+"/configuration", ServiceAPIVersion, QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]CorsConfiguration, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &configs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// GetValidationService reads the Validation service of the client's realm.
+func (c *Client) GetValidationService(ctx context.Context) (*ValidationService, error) {
+	var s ValidationService
+	if err := c.Get(ctx, c.RealmPath(validationServicePath), ServiceAPIVersion, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateValidationService writes the Validation service, creating it if required.
+func (c *Client) UpdateValidationService(ctx context.Context, s ValidationService) (*ValidationService, error) {
+	var result ValidationService
+	if err := c.putService(ctx, c.RealmPath(validationServicePath), s, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteValidationService removes the realm's Validation service.
+func (c *Client) DeleteValidationService(ctx context.Context) error {
+	return c.deleteService(ctx, c.RealmPath(validationServicePath))
+}
+
+// putService updates a realm service and falls back to creating it when the
+// realm does not have the service yet.
+func (c *Client) putService(ctx context.Context, path string, in, out interface{}) error {
+	err := c.Put(ctx, path, ServiceAPIVersion, in, out)
+	if IsNotFound(err) {
+		return c.Post(ctx, path+"?_action=create", ServiceAPIVersion, in, out)
+	}
+	return err
+}
+
+// deleteService removes a realm service, treating an already missing service
+// as deleted.
+func (c *Client) deleteService(ctx context.Context, path string) error {
+	if err := c.Delete(ctx, path, ServiceAPIVersion, nil); err != nil && !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// corsConfiguration is a CORS configuration as AM 7.2 returns it.
+const corsConfiguration = `{
+  "_id": "frontend",
+  "_rev": "-1189912153",
+  "maxAge": 600,
+  "exposedHeaders": [],
+  "acceptedHeaders": ["authorization", "accept-api-version", "content-type"],
+  "acceptedOrigins": ["https://app.example.com"],
+  "enabled": true,
+  "allowCredentials": true,
+  "acceptedMethods": ["GET", "POST", "PUT", "DELETE"],
+  "_type": {"_id": "configuration", "name": "Cors Configuration", "collection": true}
+}`
+
+func TestCorsConfigurations(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		switch {
+		case r.URL.Path == "/am/json/global-config/services/CorsService":
+			fmt.Fprint(w, `{"_id": "", "_rev": "1", "enabled": false, "_type": {"_id": "CorsService", "name": "CORS Service", "collection": false}}`)
+		case r.URL.Path == "/am/json/global-config/services/CorsService/configuration":
+			fmt.Fprint(w, `{"result": [`+corsConfiguration+`], "resultCount": 1, "pagedResultsCookie": null, `+
+				`"totalPagedResultsPolicy": "NONE", "totalPagedResults": -1, "remainingPagedResults": -1}`)
+		case r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &body)
+			fmt.Fprint(w, corsConfiguration)
+		default:
+			fmt.Fprint(w, corsConfiguration)
+		}
+	})
+	ctx := context.Background()
+
+	want := CorsConfiguration{
+		ID:               "frontend",
+		Enabled:          true,
+		AcceptedOrigins:  []string{"https://app.example.com"},
+		AcceptedMethods:  []string{"GET", "POST", "PUT", "DELETE"},
+		AcceptedHeaders:  []string{"authorization", "accept-api-version", "content-type"},
+		ExposedHeaders:   []string{},
+		MaxAge:           600,
+		AllowCredentials: true,
+	}
+
+	configs, err := c.ListCorsConfigurations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || !reflect.DeepEqual(configs[0], want) {
+		t.Errorf("ListCorsConfigurations = %+v, want %+v", configs, want)
+	}
+
+	got, err := c.GetCorsConfiguration(ctx, "frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("GetCorsConfiguration = %+v, want %+v", *got, want)
+	}
+
+	if _, err := c.PutCorsConfiguration(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+	if body["_id"] != "frontend" || body["acceptedOrigins"] == nil {
+		t.Errorf("PutCorsConfiguration sent %v, want the configuration with its _id", body)
+	}
+
+	if err := c.DeleteCorsConfiguration(ctx, "frontend"); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := c.GetCorsService(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Enabled {
+		t.Errorf("GetCorsService = %+v, want the service disabled", service)
+	}
+
+	wantRequests := []string{
+		"GET /am/json/global-config/services/CorsService/configuration?_queryFilter=true",
+		"GET /am/json/global-config/services/CorsService/configuration/frontend?",
+		"PUT /am/json/global-config/services/CorsService/configuration/frontend?",
+		"DELETE /am/json/global-config/services/CorsService/configuration/frontend?",
+		"GET /am/json/global-config/services/CorsService?",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %q, want %q", requests, wantRequests)
+	}
+}
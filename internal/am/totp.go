@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// TOTP generates the time-based one-time passwords of an OATH device
+// registration, as the OATH Token Verifier node checks them.
+type TOTP struct {
+	secret string
+	opts   totp.ValidateOpts
+
+	mu       sync.Mutex
+	lastStep int64
+}
+
+// ParseTOTPSecret accepts either the base32 secret of a registration, which
+// uses AM's defaults of 6 digits, 30 seconds and SHA1, or an otpauth://totp/
+// URI that carries its own digits, period and algorithm.
+func ParseTOTPSecret(secret string) (*TOTP, error) {
+	t := &TOTP{
+		secret: strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", "")),
+		opts:   totp.ValidateOpts{Period: 30, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1},
+	}
+	if strings.HasPrefix(strings.TrimSpace(secret), "otpauth://") {
+		key, err := otp.NewKeyFromURL(strings.TrimSpace(secret))
+		if err != nil {
+			return nil, err
+		}
+		if key.Type() != "totp" {
+			return nil, fmt.Errorf("otpauth URI must be of type totp, got %q", key.Type())
+		}
+		t.secret = strings.ToUpper(key.Secret())
+		t.opts = totp.ValidateOpts{Period: uint(key.Period()), Digits: key.Digits(), Algorithm: key.Algorithm()}
+	}
+	if _, err := totp.GenerateCodeCustom(t.secret, time.Now(), t.opts); err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	return t, nil
+}
+
+// Code returns the code of the current time step. AM rejects a code whose
+// time step has already been used to sign in, so when the previous call used
+// the current step, Code waits for the next one.
+func (t *TOTP) Code(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	period := int64(t.opts.Period)
+	now := time.Now()
+	if step := now.Unix() / period; step <= t.lastStep {
+		wait := time.Unix((t.lastStep+1)*period, 0).Sub(now)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+		}
+		now = time.Now()
+	}
+
+	code, err := totp.GenerateCodeCustom(t.secret, now, t.opts)
+	if err != nil {
+		return "", err
+	}
+	t.lastStep = now.Unix() / period
+	return code, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// URLPattern is an AM URL resource pattern, as used by policies, agent
+// not-enforced lists and the validation service. Its rules are:
+//
+//   - `*` matches any run of characters, including `/`, but never the `?`
+//     that starts the query string;
+//   - `-*-` matches any run of characters within a single path segment, and
+//     is not allowed in the query string;
+//   - a pattern without `?` only matches URLs without a query string, and a
+//     pattern with one only matches URLs with one; query parameters are
+//     compared after sorting, so their order does not matter;
+//   - the scheme and host are compared case-insensitively, the default port
+//     of the scheme may be omitted, and a `*` port also matches the default;
+//   - repeated slashes in the path are collapsed and an empty path means `/`.
+type URLPattern struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+const (
+	urlWildcard        = "*"
+	urlSegmentWildcard = "-*-"
+)
+
+var urlDefaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// ParseURLPattern checks pattern and prepares it for matching.
+func ParseURLPattern(pattern string) (*URLPattern, error) {
+	if strings.ContainsAny(pattern, " \t\r\n") {
+		return nil, fmt.Errorf("must not contain whitespace")
+	}
+	if strings.Contains(pattern, "#") {
+		return nil, fmt.Errorf("must not contain a fragment")
+	}
+	u, err := splitURL(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if u.scheme != "http" && u.scheme != "https" && !strings.Contains(u.scheme, urlWildcard) {
+		return nil, fmt.Errorf("scheme must be http or https")
+	}
+	if u.port != "" && !strings.Contains(u.port, urlWildcard) {
+		if n, err := strconv.Atoi(u.port); err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid port %q", u.port)
+		}
+	}
+	if u.hasQuery && strings.Contains(u.query, urlSegmentWildcard) {
+		return nil, fmt.Errorf("%s must not be used in the query string", urlSegmentWildcard)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	b.WriteString(wildcardRegexp(u.scheme, "[^:/?]*"))
+	b.WriteString("://")
+	b.WriteString(wildcardRegexp(u.host, "[^/?]*"))
+	switch {
+	case u.port == urlWildcard:
+		b.WriteString("(:[0-9]+)?")
+	case u.port != "" && u.port != urlDefaultPorts[u.scheme]:
+		b.WriteString(":")
+		b.WriteString(wildcardRegexp(u.port, "[0-9]*"))
+	}
+	b.WriteString(wildcardRegexp(u.path, "[^?]*"))
+	if u.hasQuery {
+		b.WriteString(`\?`)
+		b.WriteString(wildcardRegexp(u.query, ".*"))
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, err
+	}
+	return &URLPattern{pattern: pattern, re: re}, nil
+}
+
+// Match reports whether rawURL matches the pattern.
+func (p *URLPattern) Match(rawURL string) (bool, error) {
+	if _, err := url.Parse(rawURL); err != nil {
+		return false, err
+	}
+	u, err := splitURL(strings.SplitN(rawURL, "#", 2)[0])
+	if err != nil {
+		return false, err
+	}
+	if strings.ContainsAny(u.scheme+u.host+u.port, urlWildcard) {
+		return false, fmt.Errorf("%q is not a URL", rawURL)
+	}
+
+	normalized := u.scheme + "://" + u.host
+	if u.port != "" && u.port != urlDefaultPorts[u.scheme] {
+		normalized += ":" + u.port
+	}
+	normalized += u.path
+	if u.hasQuery {
+		normalized += "?" + u.query
+	}
+	return p.re.MatchString(normalized), nil
+}
+
+// String returns the pattern as written.
+func (p *URLPattern) String() string {
+	return p.pattern
+}
+
+// splitURLParts are the normalized components of a URL or URL pattern.
+type splitURLParts struct {
+	scheme, host, port, path, query string
+	hasQuery                        bool
+}
+
+// splitURL splits and normalizes a URL or URL pattern. net/url is not used
+// because it rejects `*` in the host and port, which patterns allow.
+func splitURL(s string) (splitURLParts, error) {
+	var u splitURLParts
+	scheme, rest, ok := strings.Cut(s, "://")
+	if !ok || scheme == "" {
+		return u, fmt.Errorf("must be an absolute URL such as https://app.example.com/*")
+	}
+	u.scheme = strings.ToLower(scheme)
+
+	rest, u.query, u.hasQuery = strings.Cut(rest, "?")
+	authority, path := rest, ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		authority, path = rest[:i], rest[i:]
+	}
+	if strings.Contains(authority, "@") {
+		return u, fmt.Errorf("must not contain user information")
+	}
+
+	// An IPv6 address is enclosed in brackets, so only a colon after the
+	// closing bracket starts the port.
+	host, port, hasPort := authority, "", false
+	if strings.HasPrefix(authority, "[") {
+		end := strings.Index(authority, "]")
+		if end < 0 {
+			return u, fmt.Errorf("missing ] in IPv6 host")
+		}
+		host, port = authority[:end+1], authority[end+1:]
+		if port != "" && !strings.HasPrefix(port, ":") {
+			return u, fmt.Errorf("invalid characters after IPv6 host")
+		}
+		port, hasPort = strings.TrimPrefix(port, ":"), port != ""
+	} else if i := strings.LastIndex(authority, ":"); i >= 0 {
+		host, port, hasPort = authority[:i], authority[i+1:], true
+		if strings.Contains(host, ":") {
+			return u, fmt.Errorf("IPv6 host must be enclosed in brackets")
+		}
+	}
+	if hasPort && port == "" {
+		return u, fmt.Errorf("empty port")
+	}
+	if host == "" || host == "[]" {
+		return u, fmt.Errorf("missing host")
+	}
+	u.host, u.port = strings.ToLower(host), port
+
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	if path == "" {
+		path = "/"
+	}
+	u.path = path
+
+	if u.hasQuery && u.query != "" {
+		params := strings.Split(u.query, "&")
+		sort.Strings(params)
+		u.query = strings.Join(params, "&")
+	}
+	return u, nil
+}
+
+// wildcardRegexp converts a pattern fragment into a regular expression in
+// which `*` becomes star and `-*-` matches within one path segment.
+func wildcardRegexp(fragment, star string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(fragment, urlSegmentWildcard) {
+		if i > 0 {
+			b.WriteString("[^/?]*")
+		}
+		for j, literal := range strings.Split(part, urlWildcard) {
+			if j > 0 {
+				b.WriteString(star)
+			}
+			b.WriteString(regexp.QuoteMeta(literal))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import "testing"
+
+func TestURLPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern, url string
+		want         bool
+	}{
+		// Exact URLs and normalization.
+		{"https://app.example.com/login", "https://app.example.com/login", true},
+		{"https://app.example.com/login", "HTTPS://App.Example.com/login", true},
+		{"https://app.example.com/login", "https://app.example.com/Login", false},
+		{"https://app.example.com", "https://app.example.com/", true},
+		{"https://app.example.com/a/b", "https://app.example.com//a///b", true},
+		{"https://app.example.com/login", "https://app.example.com/login#top", true},
+
+		// Default ports.
+		{"https://app.example.com/", "https://app.example.com:443/", true},
+		{"https://app.example.com:443/", "https://app.example.com/", true},
+		{"http://app.example.com:80/", "http://app.example.com/", true},
+		{"http://app.example.com/", "http://app.example.com:443/", false},
+		{"https://app.example.com:8443/", "https://app.example.com/", false},
+		{"https://app.example.com:8443/", "https://app.example.com:8443/", true},
+		{"https://app.example.com:*/", "https://app.example.com/", true},
+		{"https://app.example.com:*/", "https://app.example.com:8443/", true},
+		{"https://app.example.com:84*/", "https://app.example.com:8443/", true},
+		{"https://app.example.com:84*/", "https://app.example.com:9443/", false},
+
+		// The * wildcard.
+		{"https://app.example.com/*", "https://app.example.com/", true},
+		{"https://app.example.com/*", "https://app.example.com/a/b/c", true},
+		{"https://app.example.com/*", "https://app.example.com/a?b=c", false},
+		{"https://*.example.com/", "https://app.example.com/", true},
+		{"https://*.example.com/", "https://example.com/", false},
+		{"*://app.example.com/", "http://app.example.com/", true},
+		{"https://app.example.com/*/end", "https://app.example.com/a/b/end", true},
+
+		// The -*- wildcard stays within a path segment.
+		{"https://app.example.com/-*-/end", "https://app.example.com/a/end", true},
+		{"https://app.example.com/-*-/end", "https://app.example.com/a/b/end", false},
+		{"https://app.example.com/-*-", "https://app.example.com/a", true},
+		{"https://app.example.com/-*-", "https://app.example.com/a/", false},
+		{"https://app.example.com/img-*-.png", "https://app.example.com/img-1.png", true},
+		{"https://app.example.com/img-*-.png", "https://app.example.com/img/1.png", false},
+
+		// Query strings.
+		{"https://app.example.com/a", "https://app.example.com/a?x=1", false},
+		{"https://app.example.com/a?*", "https://app.example.com/a", false},
+		{"https://app.example.com/a?*", "https://app.example.com/a?x=1", true},
+		{"https://app.example.com/a?x=1&y=2", "https://app.example.com/a?y=2&x=1", true},
+		{"https://app.example.com/a?y=*&x=1", "https://app.example.com/a?x=1&y=anything", true},
+		{"https://app.example.com/a?x=1", "https://app.example.com/a?x=2", false},
+		{"https://app.example.com/*?*", "https://app.example.com/a/b?c=d", true},
+
+		// IPv6 hosts.
+		{"http://[::1]:8080/*", "http://[::1]:8080/am", true},
+		{"http://[::1]:8080/*", "http://[::1]:9090/am", false},
+		{"http://[::1]:8080/*", "http://[::1]/am", false},
+		{"http://[::1]/*", "http://[::1]:80/am", true},
+		{"http://[::1]:*/*", "http://[::1]:8080/am", true},
+		{"http://[2001:DB8::1]/", "http://[2001:db8::1]/", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.url, func(t *testing.T) {
+			p, err := ParseURLPattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("ParseURLPattern: %s", err)
+			}
+			got, err := p.Match(tt.url)
+			if err != nil {
+				t.Fatalf("Match: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("Match = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseURLPatternErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"",
+		"app.example.com/*",
+		"/relative/*",
+		"ftp://app.example.com/",
+		"https://app.example.com/ a",
+		"https://app.example.com/#top",
+		"https://user@app.example.com/",
+		"https:///path",
+		"https://app.example.com:/",
+		"https://app.example.com:0/",
+		"https://app.example.com:65536/",
+		"https://app.example.com:http/",
+		"https://app.example.com/?a=-*-",
+		"http://[::1/",
+		"http://[::1]8080/",
+		"http://[]:8080/",
+		"http://::1/",
+	} {
+		if _, err := ParseURLPattern(pattern); err == nil {
+			t.Errorf("ParseURLPattern(%q) succeeded, want an error", pattern)
+		}
+	}
+}
+
+func TestURLPatternMatchErrors(t *testing.T) {
+	p, err := ParseURLPattern("https://*/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, url := range []string{
+		"app.example.com/",
+		"https://*.example.com/",
+		"https://app.example.com:*/",
+		"http://[::1/",
+		"https://app.example.com/%zz",
+	} {
+		if _, err := p.Match(url); err == nil {
+			t.Errorf("Match(%q) succeeded, want an error", url)
+		}
+	}
+}
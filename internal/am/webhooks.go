@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package am
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Webhook is a webhook of the realm's Webhook service. Journeys reference it
+// by its ID, for example from the Register Logout Webhook node.
+type Webhook struct {
+	ID      string            `json:"_id"`
+	URL     string            `json:"url"`
+	Body    string            `json:"body"`
+	Headers map[string]string `json:"headers"`
+}
+
+func webhookPath(name string) string {
+	return "/realm-config/webhooks/" + url.PathEscape(name)
+}
+
+// GetWebhook reads a webhook of the client's realm.
+func (c *Client) GetWebhook(ctx context.Context, name string) (*Webhook, error) {
+	var w Webhook
+	if err := c.Get(ctx, c.RealmPath(webhookPath(name)), ServiceAPIVersion, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// PutWebhook creates or replaces a webhook of the client's realm.
+func (c *Client) PutWebhook(ctx context.Context, w Webhook) (*Webhook, error) {
+	var result Webhook
+	if err := c.Put(ctx, c.RealmPath(webhookPath(w.ID)), ServiceAPIVersion, w, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteWebhook deletes a webhook of the client's realm.
+func (c *Client) DeleteWebhook(ctx context.Context, name string) error {
+	return c.Delete(ctx, c.RealmPath(webhookPath(name)), ServiceAPIVersion, nil)
+}
+
+// ListWebhooks returns every webhook of the client's realm.
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	results, err := c.Query(ctx, c.RealmPath("/realm-config/webhooks"), ServiceAPIVersion, QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+	webhooks := make([]Webhook, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal(r, &webhooks[i]); err != nil {
+			return nil, err
+		}
+	}
+	return webhooks, nil
+}
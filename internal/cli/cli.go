@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package cli implements the subcommands of the provider binary, which talk
+// to AM directly rather than through Terraform.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// command is a subcommand. run returns the exit code of the process.
+type command struct {
+	synopsis string
+	run      func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = map[string]command{
+	"doctor":   {"Check connectivity, authentication and privileges before running Terraform", runDoctor},
+	"drift":    {"Report the differences between a state file and AM, and the objects Terraform does not manage", runDrift},
+	"generate": {"Write Terraform configuration and import blocks for the objects of a realm", runGenerate},
+}
+
+// IsCommand reports whether name is a subcommand, as opposed to the flags the
+// plugin server is started with.
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok || name == "help"
+}
+
+// Run runs the subcommand args[0] with the remaining arguments.
+func Run(args []string, stdout, stderr io.Writer) int {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(stderr)
+		if args[0] == "help" {
+			return 0
+		}
+		return 2
+	}
+	return cmd.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: terraform-provider-fram <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].synopsis)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run a command with -help for its flags. Without a command the provider plugin server is started.")
+}
+
+// connectionFlags are the provider settings every subcommand accepts. Flags
+// that are not set fall back to the FRAM_* environment variables, as the
+// provider configuration does.
+type connectionFlags struct {
+	host, username, password, realm, authJourney, totpSecret string
+}
+
+func (c *connectionFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.host, "host", "", "AM URL including the application context, for example https://am.example.com/am")
+	fs.StringVar(&c.username, "username", "", "administrator to sign in as")
+	fs.StringVar(&c.password, "password", "", "password of the administrator")
+	fs.StringVar(&c.realm, "realm", "", "realm to work on, for example /alpha")
+	fs.StringVar(&c.authJourney, "auth-journey", "", "journey of the root realm to sign in through")
+	fs.StringVar(&c.totpSecret, "totp-secret", "", "base32 secret or otpauth:// URI answering the one-time password prompt of -auth-journey")
+}
+
+// model returns the settings as the provider configuration, leaving the
+// flags that are not set to the environment and the provider defaults.
+func (c *connectionFlags) model() provider.FRAMProviderModel {
+	value := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+	return provider.FRAMProviderModel{
+		Host:        value(c.host),
+		Username:    value(c.username),
+		Password:    value(c.password),
+		Realm:       value(c.realm),
+		AuthJourney: value(c.authJourney),
+		TOTPSecret:  value(c.totpSecret),
+	}
+}
+
+// newFlagSet returns a flag set that reports errors to stderr instead of
+// exiting.
+func newFlagSet(name, usage string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-fram %s [flags]\n\n%s\n\nFlags:\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// printDiagnostics writes diags to w and reports whether they hold an error.
+func printDiagnostics(w io.Writer, diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		severity := "Warning"
+		if d.Severity() == diag.SeverityError {
+			severity = "Error"
+		}
+		fmt.Fprintf(w, "%s: %s\n", severity, d.Summary())
+		if detail := strings.TrimSpace(d.Detail()); detail != "" {
+			fmt.Fprintf(w, "  %s\n", strings.ReplaceAll(detail, "\n", "\n  "))
+		}
+	}
+	return diags.HasError()
+}
@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/darkedges/terraform-provider-fram/internal/provider"
+)
+
+const doctorUsage = `Checks that the provider can work with AM using the given settings, which
+default to the FRAM_* environment variables the provider reads: DNS and TLS,
+the serverinfo endpoint, authentication, the realm, the privileges of the
+administrator and the AM version.
+
+Exit codes: 0 when every check passes or only warns, 1 when a check fails,
+2 when the flags are invalid.`
+
+// certificateExpiryWarning is how long before it expires a certificate is
+// reported.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+type checkStatus string
+
+const (
+	checkPass checkStatus = "PASS"
+	checkWarn checkStatus = "WARN"
+	checkFail checkStatus = "FAIL"
+	checkSkip checkStatus = "SKIP"
+)
+
+// checkResult is one line of the doctor report. Hint tells the user how to
+// fix a check that did not pass.
+type checkResult struct {
+	Name    string
+	Status  checkStatus
+	Message string
+	Hint    string
+}
+
+type doctor struct {
+	ctx     context.Context
+	client  *provider.FRAMClient
+	results []checkResult
+}
+
+func (d *doctor) add(name string, status checkStatus, message, hint string) {
+	d.results = append(d.results, checkResult{Name: name, Status: status, Message: message, Hint: hint})
+}
+
+func (d *doctor) skip(because string, names ...string) {
+	for _, name := range names {
+		d.add(name, checkSkip, "skipped, "+because, "")
+	}
+}
+
+func runDoctor(args []string, stdout, stderr io.Writer) int {
+	var conn connectionFlags
+	var timeout time.Duration
+
+	fs := newFlagSet("doctor", doctorUsage, stderr)
+	conn.register(fs)
+	fs.DurationVar(&timeout, "timeout", time.Minute, "time allowed for all checks together")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	d := &doctor{ctx: ctx}
+	defer func() {
+		if d.client != nil {
+			d.client.AM.SignOut(ctx)
+		}
+	}()
+	d.run(conn)
+
+	if d.client != nil {
+		fmt.Fprintf(stdout, "Host:  %s\nRealm: %s\nUser:  %s\n\n", d.client.AM.HostURL, d.client.AM.Realm, d.client.AM.Auth.Username)
+	}
+	counts := map[checkStatus]int{}
+	for _, r := range d.results {
+		counts[r.Status]++
+		fmt.Fprintf(stdout, "[%s] %-18s %s\n", r.Status, r.Name, r.Message)
+		if r.Hint != "" && r.Status != checkPass {
+			fmt.Fprintf(stdout, "       Hint: %s\n", r.Hint)
+		}
+	}
+	fmt.Fprintf(stdout, "\n%d passed, %d warnings, %d failed, %d skipped\n", counts[checkPass], counts[checkWarn], counts[checkFail], counts[checkSkip])
+
+	if counts[checkFail] > 0 {
+		return 1
+	}
+	return 0
+}
+
+// run performs the checks in order. A check that fails skips the checks that
+// depend on it, so the report points at the first problem.
+func (d *doctor) run(conn connectionFlags) {
+	const (
+		settings   = "Settings"
+		dns        = "DNS"
+		tlsCheck   = "TLS"
+		serverInfo = "Server info"
+		auth       = "Authentication"
+		realm      = "Realm"
+		privileges = "Privileges"
+		version    = "AM version"
+	)
+
+	client, diags := provider.NewFRAMClient(conn.model())
+	if diags.HasError() {
+		var msgs []string
+		for _, e := range diags.Errors() {
+			msgs = append(msgs, e.Summary()+": "+e.Detail())
+		}
+		d.add(settings, checkFail, strings.Join(msgs, "; "), "Fix the flag or FRAM_* environment variable named in the message.")
+		d.skip("the settings are invalid", dns, tlsCheck, serverInfo, auth, realm, privileges, version)
+		return
+	}
+	d.client = client
+	d.add(settings, checkPass, "settings are valid", "")
+
+	u, err := url.Parse(client.AM.HostURL)
+	if err != nil || u.Hostname() == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		d.add(dns, checkFail, fmt.Sprintf("%q is not an http or https URL", client.AM.HostURL),
+			"Set -host or FRAM_HOST to the AM URL including its context, for example https://am.example.com/am.")
+		d.skip("the host is invalid", tlsCheck, serverInfo, auth, realm, privileges, version)
+		return
+	}
+
+	if !d.checkDNS(dns, u.Hostname()) {
+		d.skip("the host does not resolve", tlsCheck, serverInfo, auth, realm, privileges, version)
+		return
+	}
+	if !d.checkTLS(tlsCheck, u) {
+		d.skip("the TLS handshake failed", serverInfo, auth, realm, privileges, version)
+		return
+	}
+	if !d.checkServerInfo(serverInfo) {
+		d.skip("the server info could not be read", auth, realm, privileges, version)
+		return
+	}
+
+	authenticated := d.checkAuthentication(auth)
+	d.checkRealm(realm)
+	if !authenticated {
+		d.skip("authentication failed", privileges, version)
+		return
+	}
+	d.checkPrivileges(privileges)
+	d.checkVersion(version)
+}
+
+func (d *doctor) checkDNS(name, host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		d.add(name, checkPass, host+" is an IP address", "")
+		return true
+	}
+	addrs, err := net.DefaultResolver.LookupHost(d.ctx, host)
+	if err != nil {
+		d.add(name, checkFail, fmt.Sprintf("unable to resolve %s: %s", host, err),
+			"Check the host name for typos, and that this machine uses a DNS server that knows it, or add it to /etc/hosts.")
+		return false
+	}
+	d.add(name, checkPass, fmt.Sprintf("%s resolves to %s", host, strings.Join(addrs, ", ")), "")
+	return true
+}
+
+func (d *doctor) checkTLS(name string, u *url.URL) bool {
+	if u.Scheme != "https" {
+		d.add(name, checkWarn, "the host uses http, so credentials are sent unencrypted", "Use https for anything but a local test server.")
+		return true
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: 10 * time.Second}, Config: &tls.Config{ServerName: u.Hostname()}}
+	conn, err := dialer.DialContext(d.ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		hint := "Check that AM, or the load balancer in front of it, listens on port " + port + " and that no firewall blocks it."
+		var unknownAuthority x509.UnknownAuthorityError
+		var hostname x509.HostnameError
+		var invalid x509.CertificateInvalidError
+		switch {
+		case errors.As(err, &unknownAuthority):
+			hint = "The certificate is not issued by a CA this machine trusts. Add the CA to the system trust store, or point SSL_CERT_FILE at a bundle that contains it."
+		case errors.As(err, &hostname):
+			hint = "The certificate does not name " + u.Hostname() + ". Use the host name the certificate was issued for."
+		case errors.As(err, &invalid):
+			hint = "The certificate has expired or is not valid yet. Renew it, and check the clock of this machine."
+		}
+		d.add(name, checkFail, fmt.Sprintf("TLS handshake with %s failed: %s", u.Host, err), hint)
+		return false
+	}
+	defer conn.Close()
+
+	cert := conn.(*tls.Conn).ConnectionState().PeerCertificates[0]
+	message := fmt.Sprintf("certificate for %s issued by %s, valid until %s", u.Hostname(), cert.Issuer.CommonName, cert.NotAfter.Format("2006-01-02"))
+	if time.Until(cert.NotAfter) < certificateExpiryWarning {
+		d.add(name, checkWarn, message, "The certificate expires within 30 days. Renew it before the provider stops connecting.")
+		return true
+	}
+	d.add(name, checkPass, message, "")
+	return true
+}
+
+func (d *doctor) checkServerInfo(name string) bool {
+	info, err := d.client.AM.GetServerInfo(d.ctx, "/")
+	if err != nil {
+		hint := "Check that the host includes the AM context, for example /am or /openam, and that AM is running."
+		if !am.IsNotFound(err) {
+			hint = "Check that AM is running and reachable from this machine, and that no proxy rewrites the /json endpoints."
+		}
+		d.add(name, checkFail, fmt.Sprintf("unable to read the server info: %s", err), hint)
+		return false
+	}
+	d.add(name, checkPass, fmt.Sprintf("AM answers, session cookie %s", info.CookieName), "")
+	return true
+}
+
+func (d *doctor) checkAuthentication(name string) bool {
+	if err := d.client.AM.Authenticate(d.ctx); err != nil {
+		hint := "Check -username and -password, or FRAM_USERNAME and FRAM_PASSWORD."
+		switch {
+		case d.client.AM.Auth.Journey != "":
+			hint = "Check that the journey exists in the root realm, and that -totp-secret matches the OATH device registered for the user."
+		case statusCode(err) == http.StatusUnauthorized:
+			hint += " If the administrator must sign in with MFA, set -auth-journey and -totp-secret."
+		}
+		d.add(name, checkFail, err.Error(), hint)
+		return false
+	}
+
+	message := "signed in as " + d.client.AM.Auth.Username
+	if d.client.AM.Auth.Journey != "" {
+		message += " through journey " + d.client.AM.Auth.Journey
+	}
+	if id, err := d.client.AM.IDFromSession(d.ctx); err == nil && id.ID != "" {
+		message += fmt.Sprintf(" (%s in realm %s)", id.ID, id.Realm)
+	}
+	d.add(name, checkPass, message, "")
+	return true
+}
+
+func (d *doctor) checkRealm(name string) {
+	realm := d.client.AM.Realm
+	if _, err := d.client.AM.GetServerInfo(d.ctx, realm); err != nil {
+		hint := "Check that the AM administrator is not blocked from the realm."
+		if am.IsNotFound(err) || statusCode(err) == http.StatusBadRequest {
+			hint = "Create the realm, or fix -realm or FRAM_REALM. Realm names are case sensitive."
+		}
+		d.add(name, checkFail, fmt.Sprintf("unable to read realm %s: %s", realm, err), hint)
+		return
+	}
+	d.add(name, checkPass, fmt.Sprintf("realm %s exists", realm), "")
+}
+
+// checkPrivileges reads one object of each area the resources manage. Realm
+// configuration and identities need the RealmAdmin privilege in the realm;
+// global configuration needs a root realm administrator, but is only used by
+// a few resources, so it is reported as a warning.
+func (d *doctor) checkPrivileges(name string) {
+	realm := d.client.AM.Realm
+	one := am.QueryParams{Fields: []string{"_id"}, PageSize: 1, Limit: 1}
+	probes := []struct {
+		area string
+		err  error
+		warn bool
+	}{
+		{area: "realm configuration"},
+		{area: "identities"},
+		{area: "global configuration", warn: true},
+	}
+	_, probes[0].err = d.client.AM.QueryJourneys(d.ctx, realm, one)
+	_, probes[1].err = d.client.AM.QueryIdentities(d.ctx, realm, "groups", one)
+	_, probes[2].err = d.client.AM.GetCorsService(d.ctx)
+
+	var readable, denied, unreadable []string
+	warnOnly := true
+	for _, p := range probes {
+		switch {
+		case p.err == nil:
+			readable = append(readable, p.area)
+			continue
+		case statusCode(p.err) == http.StatusForbidden || statusCode(p.err) == http.StatusUnauthorized:
+			denied = append(denied, p.area)
+		default:
+			unreadable = append(unreadable, fmt.Sprintf("%s (%s)", p.area, p.err))
+		}
+		warnOnly = warnOnly && p.warn
+	}
+
+	switch {
+	case len(denied) == 0 && len(unreadable) == 0:
+		d.add(name, checkPass, "can read "+strings.Join(readable, ", "), "")
+	case warnOnly:
+		d.add(name, checkWarn, "cannot read "+strings.Join(append(denied, unreadable...), ", "),
+			"fram_cors, fram_server, fram_server_defaults and fram_site need a root realm administrator such as amAdmin.")
+	case len(denied) > 0:
+		d.add(name, checkFail, "access denied to "+strings.Join(denied, ", ")+"; can read "+orNothing(readable),
+			fmt.Sprintf("Grant %s the RealmAdmin privilege in realm %s, or use an administrator of the root realm.", d.client.AM.Auth.Username, realm))
+	default:
+		d.add(name, checkFail, "unable to read "+strings.Join(unreadable, ", "), "Check the AM debug logs for the cause of the error.")
+	}
+}
+
+func (d *doctor) checkVersion(name string) {
+	minimum := provider.MinimumAMVersion()
+	v, err := d.client.AM.Version(d.ctx)
+	if err != nil {
+		d.add(name, checkWarn, fmt.Sprintf("unable to read the version: %s", err),
+			"The provider skips its version checks; resources the server does not support fail with an API error instead.")
+		return
+	}
+	if !v.AtLeast(minimum) {
+		d.add(name, checkFail, fmt.Sprintf("AM %s is older than %s, the oldest release the provider supports", v, minimum), "Upgrade AM.")
+		return
+	}
+	d.add(name, checkPass, fmt.Sprintf("AM %s", v), "")
+}
+
+// statusCode returns the HTTP status of an AM error response, or 0.
+func statusCode(err error) int {
+	var apiErr *am.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func orNothing(items []string) string {
+	if len(items) == 0 {
+		return "nothing"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/darkedges/terraform-provider-fram/internal/fakeam"
+)
+
+func TestDoctor(t *testing.T) {
+	rejectSignIn := func(w http.ResponseWriter, r *http.Request, path string) bool {
+		if path != "/realms/root/authenticate" {
+			return false
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code": 401, "reason": "Unauthorized", "message": "Access Denied"}`)
+		return true
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		handle  func(w http.ResponseWriter, r *http.Request, path string) bool
+		code    int
+		want    []string
+		signOut bool
+	}{
+		{
+			name:    "pass",
+			code:    0,
+			want:    []string{"[PASS] Authentication", "[PASS] Privileges", "[PASS] AM version         AM 7.2.0", "0 failed"},
+			signOut: true,
+		},
+		{
+			name:    "version too old",
+			version: "6.0.0",
+			code:    1,
+			want:    []string{"[PASS] Authentication", "[FAIL] AM version         AM 6.0.0 is older than 6.5.0"},
+			signOut: true,
+		},
+		{
+			name:   "auth failure",
+			handle: rejectSignIn,
+			code:   1,
+			want: []string{
+				"[FAIL] Authentication", "Hint: Check -username and -password",
+				"[SKIP] Privileges         skipped, authentication failed",
+				"[SKIP] AM version         skipped, authentication failed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeam.AM{
+				Objects: map[string]string{
+					"/realms/root/realm-config/authentication/authenticationtrees/trees": `{"result": []}`,
+					"/realms/root/groups":                 `{"result": []}`,
+					"/global-config/services/CorsService": `{"_id": "", "enabled": false}`,
+				},
+				Version: tt.version,
+				Handle:  tt.handle,
+			}
+			host := fake.Start(t)
+
+			var stdout, stderr bytes.Buffer
+			code := Run([]string{"doctor", "-host", host, "-username", "amadmin", "-password", "password"}, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("doctor exited with %d, want %d: %s%s", code, tt.code, stderr.String(), stdout.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("report does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+
+			var signedOut bool
+			for _, req := range fake.Requests() {
+				signedOut = signedOut || req == "POST /realms/root/sessions?_action=logout"
+			}
+			if signedOut != tt.signOut {
+				t.Errorf("signed out = %t, want %t; requests: %v", signedOut, tt.signOut, fake.Requests())
+			}
+		})
+	}
+}
@@ -0,0 +1,551 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/darkedges/terraform-provider-fram/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const driftUsage = `Compares the fram_* resources of a Terraform state file with AM, without a
+plan, and lists the objects of the same realms that Terraform does not
+manage. Nothing is written to AM.
+
+Resources are read with the given settings, which default to the FRAM_*
+environment variables, as the state file does not record the provider
+configuration. Resources with a realm attribute are read in that realm, and
+its unmanaged objects are listed too.
+
+Exit codes: 0 when the report was written, 1 on error, 2 when the flags are
+invalid. With -detailed-exitcode, 3 when drifted, deleted or unmanaged
+objects were found.`
+
+// Drift statuses of a managed resource.
+const (
+	driftInSync  = "in_sync"
+	driftChanged = "drifted"
+	driftDeleted = "deleted"
+	driftError   = "error"
+)
+
+// driftReport is the report the drift subcommand writes, and its JSON format.
+type driftReport struct {
+	Host        string            `json:"host"`
+	Realms      []string          `json:"realms"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Managed     []managedDrift    `json:"managed"`
+	Unmanaged   []unmanagedObject `json:"unmanaged"`
+	Errors      []string          `json:"errors,omitempty"`
+}
+
+type managedDrift struct {
+	Address     string          `json:"address"`
+	Type        string          `json:"type"`
+	ID          string          `json:"id"`
+	Status      string          `json:"status"`
+	Differences []attributeDiff `json:"differences,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// attributeDiff is an attribute whose value in AM differs from the state.
+// The values of sensitive attributes are left out.
+type attributeDiff struct {
+	Attribute string      `json:"attribute"`
+	Sensitive bool        `json:"sensitive,omitempty"`
+	State     interface{} `json:"state"`
+	Live      interface{} `json:"live"`
+}
+
+// unmanagedObject is an object of a realm no resource of the state manages.
+type unmanagedObject struct {
+	Realm string `json:"realm"`
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+}
+
+// stateFile is the part of a Terraform state file (format version 4) the
+// report needs.
+type stateFile struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   interface{}     `json:"index_key"`
+			Attributes json.RawMessage `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// importEntityTypes are the entity kinds of fram_config_import and
+// fram_amster_import that are listed as unmanaged when no import covers them.
+// Scripts are left out, as AM lists its built-in scripts in every realm.
+var importEntityTypes = []string{"journey", "oauth2Client"}
+
+func runDrift(args []string, stdout, stderr io.Writer) int {
+	var conn connectionFlags
+	var statePath, format, out string
+	var detailed bool
+
+	fs := newFlagSet("drift", driftUsage, stderr)
+	conn.register(fs)
+	fs.StringVar(&statePath, "state", "terraform.tfstate", "state file to compare, for example the output of terraform state pull")
+	fs.StringVar(&format, "format", "markdown", "report format, json or markdown")
+	fs.StringVar(&out, "out", "", "file to write the report to, standard output by default")
+	fs.BoolVar(&detailed, "detailed-exitcode", false, "exit with 3 when drift or unmanaged objects are found")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if format != "json" && format != "markdown" {
+		fmt.Fprintf(stderr, "Error: -format must be json or markdown, got %q\n", format)
+		return 2
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		fmt.Fprintf(stderr, "Error: unable to parse state file %s: %s\n", statePath, err)
+		return 1
+	}
+	if state.Version != 4 {
+		fmt.Fprintf(stderr, "Error: state file %s has format version %d, only version 4 is supported\n", statePath, state.Version)
+		return 1
+	}
+
+	ctx := context.Background()
+	client, diags := provider.NewFRAMClient(conn.model())
+	if printDiagnostics(stderr, diags) {
+		return 1
+	}
+
+	defer client.AM.SignOut(ctx)
+
+	report := driftReport{Host: client.AM.HostURL, GeneratedAt: time.Now().UTC()}
+	// managed holds the IDs the state manages, keyed by realm and type; the
+	// objects of global types are keyed by the empty realm.
+	managed := map[string]map[string]map[string]bool{}
+	markManaged := func(realm, typeName, id string) {
+		if provider.IsGlobalResourceType(typeName) {
+			realm = ""
+		}
+		if managed[realm] == nil {
+			managed[realm] = map[string]map[string]bool{}
+		}
+		if managed[realm][typeName] == nil {
+			managed[realm][typeName] = map[string]bool{}
+		}
+		managed[realm][typeName][id] = true
+	}
+	realms := map[string]bool{client.AM.Realm: true}
+
+	for _, r := range state.Resources {
+		if r.Mode != "managed" || !strings.HasPrefix(r.Type, "fram_") {
+			continue
+		}
+		for _, instance := range r.Instances {
+			entry := managedDrift{Address: resourceAddress(r.Module, r.Type, r.Name, instance.IndexKey), Type: r.Type}
+			prior, live, diags := provider.RefreshLiveResource(ctx, client, r.Type, instance.Attributes)
+			if prior != nil {
+				entry.ID = prior.ImportID
+				realm := stateRealm(prior, client.AM.Realm)
+				realms[realm] = true
+				markManaged(realm, r.Type, prior.ImportID)
+				for key := range importedEntities(prior) {
+					kind, id, _ := strings.Cut(key, "/")
+					markManaged(realm, kind, id)
+				}
+			}
+			switch {
+			case diags.HasError():
+				entry.Status = driftError
+				entry.Error = diagnosticsText(diags)
+			case live == nil:
+				entry.Status = driftDeleted
+			default:
+				entry.Differences = diffValues("", prior.Schema.Attributes, prior.State, live.State, false)
+				entry.Status = driftInSync
+				if len(entry.Differences) > 0 {
+					entry.Status = driftChanged
+				}
+			}
+			report.Managed = append(report.Managed, entry)
+		}
+	}
+
+	for realm := range realms {
+		report.Realms = append(report.Realms, realm)
+	}
+	sort.Strings(report.Realms)
+	for _, realm := range report.Realms {
+		report.listUnmanaged(ctx, client, realm, realm == report.Realms[0], managed)
+	}
+
+	var rendered []byte
+	if format == "json" {
+		rendered, err = json.MarshalIndent(report, "", "  ")
+		rendered = append(rendered, '\n')
+	} else {
+		rendered = []byte(report.markdown())
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	if out == "" {
+		_, err = stdout.Write(rendered)
+	} else {
+		err = os.WriteFile(out, rendered, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	for _, m := range report.Managed {
+		if m.Status == driftError {
+			return 1
+		}
+	}
+	if len(report.Errors) > 0 {
+		return 1
+	}
+	if detailed && (len(report.Unmanaged) > 0 || report.hasDrift()) {
+		return 3
+	}
+	return 0
+}
+
+// listUnmanaged adds the objects of realm the state does not manage. Global
+// objects are listed with the first realm only. Every realm is read with the
+// session of client, as signing in again would use up another one-time
+// password.
+func (report *driftReport) listUnmanaged(ctx context.Context, client *provider.FRAMClient, realm string, withGlobal bool, managed map[string]map[string]map[string]bool) {
+	client, err := client.WithRealm(realm)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("realm %s: %s", realm, err))
+		return
+	}
+
+	add := func(typeName, id string) {
+		objectRealm := realm
+		if provider.IsGlobalResourceType(typeName) {
+			objectRealm = ""
+		}
+		if !managed[objectRealm][typeName][id] {
+			report.Unmanaged = append(report.Unmanaged, unmanagedObject{Realm: objectRealm, Type: typeName, ID: id})
+		}
+	}
+
+	for _, typeName := range provider.LiveResourceTypes() {
+		if provider.IsGlobalResourceType(typeName) && !withGlobal {
+			continue
+		}
+		ids, diags := provider.ListLiveResourceIDs(ctx, client, typeName)
+		if typeName == "fram_session" || typeName == "fram_validation" {
+			// Singleton services only count when the realm has them.
+			var resources []provider.LiveResource
+			resources, diags = provider.ListLiveResources(ctx, client, typeName)
+			ids = nil
+			for _, r := range resources {
+				ids = append(ids, r.ImportID)
+			}
+		}
+		if diags.HasError() {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s in realm %s: %s", typeName, realm, diagnosticsText(diags)))
+			continue
+		}
+		for _, id := range ids {
+			add(typeName, id)
+		}
+	}
+
+	for _, kind := range importEntityTypes {
+		ids, err := listEntityIDs(ctx, client.AM, realm, kind)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s objects in realm %s: %s", kind, realm, err))
+			continue
+		}
+		for _, id := range ids {
+			add(kind, id)
+		}
+	}
+}
+
+func listEntityIDs(ctx context.Context, client *am.Client, realm, kind string) ([]string, error) {
+	params := am.QueryParams{Fields: []string{"_id"}}
+	var ids []string
+	switch kind {
+	case "journey":
+		journeys, err := client.QueryJourneys(ctx, realm, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, j := range journeys {
+			ids = append(ids, j.ID)
+		}
+	case "oauth2Client":
+		clients, err := client.QueryOAuth2Clients(ctx, realm, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range clients {
+			ids = append(ids, c.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (report *driftReport) hasDrift() bool {
+	for _, m := range report.Managed {
+		if m.Status == driftChanged || m.Status == driftDeleted {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceAddress returns the address Terraform shows for a resource
+// instance, such as module.auth.fram_webhook.logout["alpha"].
+func resourceAddress(module, typeName, name string, indexKey interface{}) string {
+	address := typeName + "." + name
+	if module != "" {
+		address = module + "." + address
+	}
+	switch key := indexKey.(type) {
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	case float64:
+		address += fmt.Sprintf("[%d]", int(key))
+	}
+	return address
+}
+
+// stateRealm returns the realm attribute of r, or realm when it has none.
+func stateRealm(r *provider.LiveResource, realm string) string {
+	var values map[string]tftypes.Value
+	if r.State.As(&values) != nil {
+		return realm
+	}
+	var s string
+	if v, ok := values["realm"]; ok && v.IsKnown() && !v.IsNull() && v.As(&s) == nil && s != "" {
+		if canonical, err := am.CanonicalRealm(s); err == nil {
+			return canonical
+		}
+	}
+	return realm
+}
+
+// importedEntities returns the keys of the entities a fram_config_import or
+// fram_amster_import resource recorded, such as "journey/Login".
+func importedEntities(r *provider.LiveResource) map[string]bool {
+	keys := map[string]bool{}
+	var values map[string]tftypes.Value
+	if r.State.As(&values) != nil {
+		return keys
+	}
+	var entities map[string]tftypes.Value
+	if v, ok := values["entities"]; !ok || v.As(&entities) != nil {
+		return keys
+	}
+	for key := range entities {
+		keys[key] = true
+	}
+	return keys
+}
+
+// diffValues compares the state and live values of the attributes, object
+// attributes and map elements below prefix. Lists and sets are compared as a
+// whole.
+func diffValues(prefix string, attributes map[string]schema.Attribute, state, live tftypes.Value, sensitive bool) []attributeDiff {
+	if state.Equal(live) {
+		return nil
+	}
+
+	typ := state.Type()
+	isObject := typ.Is(tftypes.Object{})
+	if !state.IsNull() && !live.IsNull() && state.IsKnown() && live.IsKnown() && (isObject || typ.Is(tftypes.Map{})) {
+		var before, after map[string]tftypes.Value
+		if state.As(&before) == nil && live.As(&after) == nil {
+			keys := map[string]bool{}
+			for k := range before {
+				keys[k] = true
+			}
+			for k := range after {
+				keys[k] = true
+			}
+			sorted := make([]string, 0, len(keys))
+			for k := range keys {
+				sorted = append(sorted, k)
+			}
+			sort.Strings(sorted)
+
+			var diffs []attributeDiff
+			for _, k := range sorted {
+				path, nested, elemSensitive := prefix+"["+fmt.Sprintf("%q", k)+"]", attributes, sensitive
+				if isObject {
+					path = k
+					if prefix != "" {
+						path = prefix + "." + k
+					}
+					a := attributes[k]
+					nested = nestedAttributes(a)
+					elemSensitive = sensitive || (a != nil && a.IsSensitive())
+				}
+				b, ok := before[k]
+				if !ok {
+					b = tftypes.NewValue(after[k].Type(), nil)
+				}
+				a, ok := after[k]
+				if !ok {
+					a = tftypes.NewValue(b.Type(), nil)
+				}
+				diffs = append(diffs, diffValues(path, nested, b, a, elemSensitive)...)
+			}
+			return diffs
+		}
+	}
+
+	d := attributeDiff{Attribute: prefix, Sensitive: sensitive}
+	if !sensitive {
+		d.State, d.Live = plainValue(state), plainValue(live)
+	}
+	return []attributeDiff{d}
+}
+
+// plainValue converts v to the Go value encoding/json renders it as.
+func plainValue(v tftypes.Value) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return s
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		return json.Number(n.Text('f', -1))
+	case typ.Is(tftypes.Bool):
+		var b bool
+		_ = v.As(&b)
+		return b
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = v.As(&elems)
+		result := make([]interface{}, len(elems))
+		for i, e := range elems {
+			result[i] = plainValue(e)
+		}
+		return result
+	default:
+		var elems map[string]tftypes.Value
+		_ = v.As(&elems)
+		result := make(map[string]interface{}, len(elems))
+		for k, e := range elems {
+			result[k] = plainValue(e)
+		}
+		return result
+	}
+}
+
+func (report *driftReport) markdown() string {
+	var b strings.Builder
+	b.WriteString("# Drift report\n\n")
+	fmt.Fprintf(&b, "AM `%s`, realms %s, generated %s.\n\n", report.Host, "`"+strings.Join(report.Realms, "`, `")+"`", report.GeneratedAt.Format(time.RFC3339))
+
+	b.WriteString("## Managed resources\n\n")
+	if len(report.Managed) == 0 {
+		b.WriteString("The state file has no fram resources.\n\n")
+	} else {
+		b.WriteString("| Resource | ID | Status |\n|---|---|---|\n")
+		for _, m := range report.Managed {
+			fmt.Fprintf(&b, "| `%s` | `%s` | %s |\n", markdownCell(m.Address), markdownCell(m.ID), m.Status)
+		}
+		b.WriteString("\n")
+	}
+	for _, m := range report.Managed {
+		switch {
+		case m.Status == driftError:
+			fmt.Fprintf(&b, "### %s\n\nUnable to read the resource: %s\n\n", m.Address, m.Error)
+		case len(m.Differences) > 0:
+			fmt.Fprintf(&b, "### %s\n\n| Attribute | State | Live |\n|---|---|---|\n", m.Address)
+			for _, d := range m.Differences {
+				if d.Sensitive {
+					fmt.Fprintf(&b, "| `%s` | _sensitive_ | _sensitive_ |\n", markdownCell(d.Attribute))
+					continue
+				}
+				fmt.Fprintf(&b, "| `%s` | %s | %s |\n", markdownCell(d.Attribute), markdownValue(d.State), markdownValue(d.Live))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("## Unmanaged objects\n\n")
+	if len(report.Unmanaged) == 0 {
+		b.WriteString("Every object found is managed by the state.\n")
+	} else {
+		b.WriteString("| Realm | Type | ID |\n|---|---|---|\n")
+		for _, u := range report.Unmanaged {
+			realm := u.Realm
+			if realm == "" {
+				realm = "(global)"
+			}
+			fmt.Fprintf(&b, "| %s | %s | `%s` |\n", markdownCell(realm), u.Type, markdownCell(u.ID))
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		b.WriteString("\n## Errors\n\n")
+		for _, e := range report.Errors {
+			fmt.Fprintf(&b, "- %s\n", markdownCell(e))
+		}
+	}
+	return b.String()
+}
+
+func markdownValue(v interface{}) string {
+	if v == nil {
+		return "_null_"
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return markdownCell(fmt.Sprint(v))
+	}
+	return "`" + markdownCell(string(encoded)) + "`"
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+// diagnosticsText joins the errors of diags into one line.
+func diagnosticsText(diags diag.Diagnostics) string {
+	var msgs []string
+	for _, d := range diags.Errors() {
+		msgs = append(msgs, strings.TrimSpace(d.Summary()+": "+d.Detail()))
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darkedges/terraform-provider-fram/internal/fakeam"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pquerna/otp/totp"
+)
+
+func TestResourceAddress(t *testing.T) {
+	tests := []struct {
+		module, typeName, name string
+		indexKey               interface{}
+		want                   string
+	}{
+		{"", "fram_webhook", "logout", nil, "fram_webhook.logout"},
+		{"", "fram_webhook", "logout", "alpha", `fram_webhook.logout["alpha"]`},
+		{"", "fram_webhook", "logout", float64(2), "fram_webhook.logout[2]"},
+		{"module.auth", "fram_webhook", "logout", "a\"b", `module.auth.fram_webhook.logout["a\"b"]`},
+		{"module.auth.module.inner", "fram_group", "admins", float64(0), "module.auth.module.inner.fram_group.admins[0]"},
+	}
+	for _, tt := range tests {
+		if got := resourceAddress(tt.module, tt.typeName, tt.name, tt.indexKey); got != tt.want {
+			t.Errorf("resourceAddress(%q, %q, %q, %v) = %s, want %s", tt.module, tt.typeName, tt.name, tt.indexKey, got, tt.want)
+		}
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	attributes := map[string]schema.Attribute{
+		"name":     schema.StringAttribute{Required: true},
+		"password": schema.StringAttribute{Optional: true, Sensitive: true},
+		"settings": schema.MapAttribute{ElementType: types.StringType, Optional: true},
+		"urls":     schema.ListAttribute{ElementType: types.StringType, Optional: true},
+		"options": schema.SingleNestedAttribute{Optional: true, Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{Optional: true},
+			"secret":  schema.StringAttribute{Optional: true, Sensitive: true},
+		}},
+	}
+	optionsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"enabled": tftypes.Bool, "secret": tftypes.String}}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":     tftypes.String,
+		"password": tftypes.String,
+		"settings": tftypes.Map{ElementType: tftypes.String},
+		"urls":     tftypes.List{ElementType: tftypes.String},
+		"options":  optionsType,
+	}}
+	object := func(values map[string]tftypes.Value) tftypes.Value {
+		all := map[string]tftypes.Value{}
+		for name, typ := range objectType.AttributeTypes {
+			all[name] = tftypes.NewValue(typ, nil)
+		}
+		for name, v := range values {
+			all[name] = v
+		}
+		return tftypes.NewValue(objectType, all)
+	}
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	settings := func(m map[string]string) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for k, v := range m {
+			values[k] = str(v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+	}
+	urls := func(s ...string) tftypes.Value {
+		values := make([]tftypes.Value, len(s))
+		for i, v := range s {
+			values[i] = str(v)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+	options := func(enabled bool, secret string) tftypes.Value {
+		return tftypes.NewValue(optionsType, map[string]tftypes.Value{"enabled": tftypes.NewValue(tftypes.Bool, enabled), "secret": str(secret)})
+	}
+
+	tests := []struct {
+		name        string
+		state, live tftypes.Value
+		want        []attributeDiff
+	}{
+		{
+			name:  "equal",
+			state: object(map[string]tftypes.Value{"name": str("a"), "settings": settings(map[string]string{"k": "1"})}),
+			live:  object(map[string]tftypes.Value{"name": str("a"), "settings": settings(map[string]string{"k": "1"})}),
+		},
+		{
+			name:  "string",
+			state: object(map[string]tftypes.Value{"name": str("a")}),
+			live:  object(map[string]tftypes.Value{"name": str("b")}),
+			want:  []attributeDiff{{Attribute: "name", State: "a", Live: "b"}},
+		},
+		{
+			name:  "map elements",
+			state: object(map[string]tftypes.Value{"settings": settings(map[string]string{"changed": "1", "removed": "2", "same": "3"})}),
+			live:  object(map[string]tftypes.Value{"settings": settings(map[string]string{"added": "4", "changed": "5", "same": "3"})}),
+			want: []attributeDiff{
+				{Attribute: `settings["added"]`, Live: "4"},
+				{Attribute: `settings["changed"]`, State: "1", Live: "5"},
+				{Attribute: `settings["removed"]`, State: "2"},
+			},
+		},
+		{
+			name:  "null map",
+			state: object(nil),
+			live:  object(map[string]tftypes.Value{"settings": settings(map[string]string{"k": "1"})}),
+			want:  []attributeDiff{{Attribute: "settings", Live: map[string]interface{}{"k": "1"}}},
+		},
+		{
+			name:  "list as a whole",
+			state: object(map[string]tftypes.Value{"urls": urls("a", "b")}),
+			live:  object(map[string]tftypes.Value{"urls": urls("a", "c")}),
+			want:  []attributeDiff{{Attribute: "urls", State: []interface{}{"a", "b"}, Live: []interface{}{"a", "c"}}},
+		},
+		{
+			name:  "sensitive",
+			state: object(map[string]tftypes.Value{"password": str("old")}),
+			live:  object(map[string]tftypes.Value{"password": str("new")}),
+			want:  []attributeDiff{{Attribute: "password", Sensitive: true}},
+		},
+		{
+			name:  "nested object",
+			state: object(map[string]tftypes.Value{"options": options(true, "old")}),
+			live:  object(map[string]tftypes.Value{"options": options(false, "new")}),
+			want: []attributeDiff{
+				{Attribute: "options.enabled", State: true, Live: false},
+				{Attribute: "options.secret", Sensitive: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffValues("", attributes, tt.state, tt.live, false)
+			if fmt.Sprintf("%#v", got) != fmt.Sprintf("%#v", tt.want) {
+				t.Errorf("diffValues =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftReportsAuthModuleSettings(t *testing.T) {
+	module := `{"_id": "Corporate", "_type": {"_id": "ldap"}, "authenticationLevel": 2, ` +
+		`"primaryLdapServer": ["ldap.example.com:389"], "userBindDN": "cn=amadmin"}`
+	host := fakeam.New(t, map[string]string{
+		"/realms/root/realm-config/authentication/modules":                   `{"result": [` + module + `]}`,
+		"/realms/root/realm-config/authentication/modules/ldap/Corporate":    module,
+		"/realms/root/realm-config/authentication/chains":                    `{"result": []}`,
+		"/realms/root/realm-config/authentication/authenticationtrees/trees": `{"result": []}`,
+		"/realms/root/realm-config/agents/OAuth2Client":                      `{"result": []}`,
+		"/realms/root/realm-config/webhooks":                                 `{"result": []}`,
+		"/realms/root/groups":                                                `{"result": []}`,
+		"/global-config/services/CorsService/configuration":                  `{"result": []}`,
+	})
+
+	// The state of a module generated or imported with the previous userBindDN.
+	statePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+	state := `{"version": 4, "resources": [{"mode": "managed", "type": "fram_auth_module", "name": "corporate", "instances": [{"attributes": {` +
+		`"id": "ldap/Corporate", "type": "ldap", "name": "Corporate", "authentication_level": 2, ` +
+		`"settings": {"primaryLdapServer": "[\"ldap.example.com:389\"]", "userBindDN": "\"cn=admin\""}}}]}]}`
+	if err := os.WriteFile(statePath, []byte(state), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"drift", "-host", host, "-state", statePath, "-format", "json"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("drift exited with %d: %s%s", code, stderr.String(), stdout.String())
+	}
+	var report driftReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Managed) != 1 {
+		t.Fatalf("managed = %+v, want one resource", report.Managed)
+	}
+	got := report.Managed[0]
+	want := managedDrift{
+		Address: "fram_auth_module.corporate",
+		Type:    "fram_auth_module",
+		ID:      "ldap/Corporate",
+		Status:  driftChanged,
+		Differences: []attributeDiff{
+			{Attribute: `settings["userBindDN"]`, Sensitive: true},
+		},
+	}
+	if fmt.Sprintf("%+v", got) != fmt.Sprintf("%+v", want) {
+		t.Errorf("managed = %+v, want %+v", got, want)
+	}
+}
+
+func TestDriftSignsInOnceForEveryRealm(t *testing.T) {
+	const secret = "JBSWY3DPEHPK3PXP"
+	// Like AM, the fake journey accepts each one-time password once.
+	var signIns int
+	used := map[string]bool{}
+	fake := &fakeam.AM{Objects: map[string]string{
+		"/global-config/services/CorsService/configuration":              `{"result": []}`,
+		"/realms/root/realms/alpha/groups":                               `{"result": [{"_id": "admins"}]}`,
+		"/realms/root/realms/alpha/realm-config/agents/OAuth2Client":     `{"result": [{"_id": "app"}]}`,
+		"/realms/root/realms/alpha/realm-config/agents/OAuth2Client/app": `{"_id": "app"}`,
+	}}
+	for _, base := range []string{"/realms/root", "/realms/root/realms/alpha"} {
+		for _, p := range []string{"/realm-config/authentication/modules", "/realm-config/authentication/chains",
+			"/realm-config/authentication/authenticationtrees/trees", "/realm-config/agents/OAuth2Client", "/realm-config/webhooks", "/groups"} {
+			if fake.Objects[base+p] == "" {
+				fake.Objects[base+p] = `{"result": []}`
+			}
+		}
+	}
+	fake.Handle = func(w http.ResponseWriter, r *http.Request, path string) bool {
+		if path != "/realms/root/authenticate" || r.URL.Query().Get("authIndexValue") != "MFA" {
+			return false
+		}
+		body, _ := io.ReadAll(r.Body)
+		if len(body) == 0 {
+			fmt.Fprint(w, `{"authId": "step", "callbacks": [`+
+				`{"type": "NameCallback", "output": [{"name": "prompt", "value": "User Name"}], "input": [{"name": "IDToken1", "value": ""}]}, `+
+				`{"type": "PasswordCallback", "output": [{"name": "prompt", "value": "Password"}], "input": [{"name": "IDToken2", "value": ""}]}, `+
+				`{"type": "PasswordCallback", "output": [{"name": "prompt", "value": "One Time Password"}], "input": [{"name": "IDToken3", "value": ""}]}]}`)
+			return true
+		}
+		var step struct {
+			Callbacks []struct {
+				Input []struct {
+					Value string `json:"value"`
+				} `json:"input"`
+			} `json:"callbacks"`
+		}
+		if err := json.Unmarshal(body, &step); err != nil || len(step.Callbacks) != 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			return true
+		}
+		code := step.Callbacks[2].Input[0].Value
+		if used[code] || !totp.Validate(code, secret) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code": 401, "reason": "Unauthorized", "message": "Login failure"}`)
+			return true
+		}
+		used[code] = true
+		signIns++
+		fmt.Fprint(w, `{"tokenId": "token", "successUrl": "/am/console", "realm": "/"}`)
+		return true
+	}
+	host := fake.Start(t)
+
+	// A configuration import of an OAuth2 client of the alpha realm makes drift
+	// read that realm as well as the root realm of the provider.
+	statePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+	state := `{"version": 4, "resources": [{"mode": "managed", "type": "fram_config_import", "name": "alpha", "instances": [{"attributes": {` +
+		`"id": "alpha", "realm": "/alpha", "content": "{\"application\": {\"app\": {\"_id\": \"app\"}}}", "entities": {"oauth2Client/app": {"hash": "", "live_hash": "", "created": true}}}}]}]}`
+	if err := os.WriteFile(statePath, []byte(state), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"drift", "-host", host, "-auth-journey", "MFA", "-totp-secret", secret, "-state", statePath, "-format", "json"}
+	if code := Run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("drift exited with %d: %s%s", code, stderr.String(), stdout.String())
+	}
+	var report driftReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(report.Realms) != "[/ /alpha]" {
+		t.Errorf("realms = %v, want / and /alpha", report.Realms)
+	}
+	if fmt.Sprintf("%+v", report.Unmanaged) != fmt.Sprintf("%+v", []unmanagedObject{{Realm: "/alpha", Type: "fram_group", ID: "admins"}}) {
+		t.Errorf("unmanaged = %+v, want the group of the alpha realm", report.Unmanaged)
+	}
+	if signIns != 1 {
+		t.Errorf("signed in %d times, want once", signIns)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/provider"
+)
+
+const generateUsage = `Reads the objects of a realm from AM and writes one <type>.tf file per
+resource type to -out, with a resource block and an import block for each
+object. Run "terraform plan" in the output directory to adopt them.
+
+Supported resource types: `
+
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	var conn connectionFlags
+	var out, only string
+
+	fs := newFlagSet("generate", generateUsage+strings.Join(provider.LiveResourceTypes(), ", "), stderr)
+	conn.register(fs)
+	fs.StringVar(&out, "out", ".", "directory to write the .tf files to")
+	fs.StringVar(&only, "types", "", "comma-separated resource types to generate, all supported types by default")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	typeNames := provider.LiveResourceTypes()
+	if only != "" {
+		typeNames = strings.Split(only, ",")
+		for i := range typeNames {
+			typeNames[i] = strings.TrimSpace(typeNames[i])
+		}
+	}
+
+	ctx := context.Background()
+	client, diags := provider.NewFRAMClient(conn.model())
+	if printDiagnostics(stderr, diags) {
+		return 1
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	// A type the administrator cannot read, such as the global CORS service
+	// for a realm administrator, does not stop the others from being written.
+	code := 0
+	for _, typeName := range typeNames {
+		resources, diags := provider.ListLiveResources(ctx, client, typeName)
+		if printDiagnostics(stderr, diags) {
+			code = 1
+			continue
+		}
+		if len(resources) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Generated by terraform-provider-fram generate from realm %s of %s.\n\n", client.AM.Realm, client.AM.HostURL)
+		names := resourceNames{}
+		for i, live := range resources {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			if err := writeResource(&b, live, names.name(live.ImportID)); err != nil {
+				fmt.Fprintf(stderr, "Error: %s\n", err)
+				return 1
+			}
+		}
+
+		file := filepath.Join(out, typeName+".tf")
+		if err := os.WriteFile(file, []byte(b.String()), 0o644); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "Wrote %d %s resources to %s\n", len(resources), typeName, file)
+	}
+	return code
+}
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// resourceNames hands out the Terraform names of the resources of a type,
+// derived from their import IDs and made unique.
+type resourceNames map[string]bool
+
+func (n resourceNames) name(id string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(id) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	base := strings.TrimSuffix(b.String(), "_")
+	switch {
+	case base == "":
+		base = "root"
+	case base[0] >= '0' && base[0] <= '9':
+		base = "_" + base
+	}
+
+	name := base
+	for i := 2; n[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[name] = true
+	return name
+}
+
+// writeResource renders live as a resource block named name, followed by the
+// import block that adopts the object. Computed-only attributes and null
+// values are left out; sensitive attributes are replaced by a comment, which
+// lists the keys of a map, so secrets are not written to disk.
+func writeResource(b *strings.Builder, live provider.LiveResource, name string) error {
+	fmt.Fprintf(b, "resource %q %q {\n", live.TypeName, name)
+	if err := writeAttributes(b, "  ", live.Schema.Attributes, live.State); err != nil {
+		return fmt.Errorf("unable to render %s.%s: %w", live.TypeName, name, err)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "import {\n  to = %s.%s\n  id = %s\n}\n", live.TypeName, name, hclString(live.ImportID))
+	return nil
+}
+
+func writeAttributes(b *strings.Builder, indent string, attributes map[string]schema.Attribute, v tftypes.Value) error {
+	var values map[string]tftypes.Value
+	if err := v.As(&values); err != nil {
+		return err
+	}
+
+	var names []string
+	for name, a := range attributes {
+		if a.IsComputed() && !a.IsOptional() && !a.IsRequired() {
+			continue
+		}
+		if values[name].IsNull() || !values[name].IsKnown() {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([][2]string, len(names))
+	for i, name := range names {
+		a := attributes[name]
+		if a.IsSensitive() {
+			comment := fmt.Sprintf("# %s is sensitive and is not written; set it before applying.", name)
+			// The keys of a map are not secret and tell what there is to set.
+			var elems map[string]tftypes.Value
+			if values[name].Type().Is(tftypes.Map{}) && values[name].As(&elems) == nil && len(elems) > 0 {
+				keys := make([]string, 0, len(elems))
+				for k := range elems {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				comment += " Its keys in AM are " + strings.Join(keys, ", ") + "."
+			}
+			lines[i] = [2]string{"", comment}
+			continue
+		}
+		s, err := hclValue(values[name], nestedAttributes(a), indent)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		lines[i] = [2]string{name, s}
+	}
+	writeAligned(b, indent, lines)
+	return nil
+}
+
+// writeAligned writes name = value lines, aligning the equals signs of
+// consecutive single-line values as terraform fmt does. Lines without a name
+// are written as they are.
+func writeAligned(b *strings.Builder, indent string, lines [][2]string) {
+	singleLine := func(l [2]string) bool {
+		return l[0] != "" && !strings.Contains(l[1], "\n")
+	}
+	for start := 0; start < len(lines); {
+		end := start + 1
+		if singleLine(lines[start]) {
+			for end < len(lines) && singleLine(lines[end]) {
+				end++
+			}
+		}
+		width := 0
+		for _, l := range lines[start:end] {
+			width = max(width, len(l[0]))
+		}
+		for _, l := range lines[start:end] {
+			if l[0] == "" {
+				fmt.Fprintf(b, "%s%s\n", indent, l[1])
+				continue
+			}
+			fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, l[0], l[1])
+		}
+		start = end
+	}
+}
+
+// nestedAttributes returns the attributes of the objects a nested attribute
+// holds, or nil for any other attribute.
+func nestedAttributes(a schema.Attribute) map[string]schema.Attribute {
+	switch a := a.(type) {
+	case schema.ListNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SingleNestedAttribute:
+		return a.Attributes
+	}
+	return nil
+}
+
+// hclValue renders v as an HCL expression. Objects are rendered with the
+// attributes of nested when it is set, so their computed-only attributes are
+// left out as well.
+func hclValue(v tftypes.Value, nested map[string]schema.Attribute, indent string) (string, error) {
+	if v.IsNull() {
+		return "null", nil
+	}
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return hclString(s), err
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		err := v.As(&n)
+		return n.Text('f', -1), err
+	case typ.Is(tftypes.Bool):
+		var bv bool
+		err := v.As(&bv)
+		return strconv.FormatBool(bv), err
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return "", err
+		}
+		return hclSequence(elems, nested, indent)
+	case typ.Is(tftypes.Object{}) && nested != nil:
+		var b strings.Builder
+		b.WriteString("{\n")
+		if err := writeAttributes(&b, indent+"  ", nested, v); err != nil {
+			return "", err
+		}
+		b.WriteString(indent + "}")
+		return b.String(), nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return "", err
+		}
+		return hclMapping(elems, nested, indent)
+	}
+	return "", fmt.Errorf("unsupported type %s", typ)
+}
+
+// hclSequence renders a list on one line, unless it holds objects.
+func hclSequence(elems []tftypes.Value, nested map[string]schema.Attribute, indent string) (string, error) {
+	rendered := make([]string, len(elems))
+	multiline := false
+	for i, e := range elems {
+		s, err := hclValue(e, nested, indent+"  ")
+		if err != nil {
+			return "", err
+		}
+		rendered[i] = s
+		multiline = multiline || strings.Contains(s, "\n")
+	}
+	if !multiline {
+		return "[" + strings.Join(rendered, ", ") + "]", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, s := range rendered {
+		b.WriteString(indent + "  " + s + ",\n")
+	}
+	b.WriteString(indent + "]")
+	return b.String(), nil
+}
+
+func hclMapping(elems map[string]tftypes.Value, nested map[string]schema.Attribute, indent string) (string, error) {
+	if len(elems) == 0 {
+		return "{}", nil
+	}
+	keys := make([]string, 0, len(elems))
+	for k := range elems {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([][2]string, len(keys))
+	for i, k := range keys {
+		s, err := hclValue(elems[k], nested, indent+"  ")
+		if err != nil {
+			return "", err
+		}
+		lines[i] = [2]string{hclKey(k), s}
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	writeAligned(&b, indent+"  ", lines)
+	b.WriteString(indent + "}")
+	return b.String(), nil
+}
+
+func hclKey(k string) string {
+	if identifierPattern.MatchString(k) {
+		return k
+	}
+	return hclString(k)
+}
+
+// hclString quotes s, escaping the template sequences HCL would otherwise
+// interpolate.
+func hclString(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
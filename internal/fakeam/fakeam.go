@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeam serves a fake AM for the tests of the provider and the CLI.
+package fakeam
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// AM is a fake AM that signs every administrator in and answers GET requests
+// with fixed JSON objects. Set its fields before calling Start.
+type AM struct {
+	// Objects are the JSON objects answered to GET requests, keyed by their
+	// path below /json, such as "/realms/root/groups".
+	Objects map[string]string
+	// Version is the version /serverinfo/version reports. Defaults to 7.2.0.
+	Version string
+	// Handle, when set, is offered every request first, with its path below
+	// /json. It returns false to leave the request to the defaults.
+	Handle func(w http.ResponseWriter, r *http.Request, path string) bool
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// New starts a fake AM that serves objects and returns the host to
+// configure, as Start does.
+func New(t *testing.T, objects map[string]string) string {
+	a := &AM{Objects: objects}
+	return a.Start(t)
+}
+
+// Start serves a until the test ends and returns the host to configure,
+// which includes the /am deployment context. It clears the FRAM_* variables
+// for the test so that only the given settings apply.
+func (a *AM) Start(t *testing.T) string {
+	t.Helper()
+	for _, env := range []string{"FRAM_HOST", "FRAM_USERNAME", "FRAM_PASSWORD", "FRAM_REALM", "FRAM_AUTH_JOURNEY", "FRAM_TOTP_SECRET"} {
+		t.Setenv(env, "")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(a.serveHTTP))
+	t.Cleanup(server.Close)
+	return server.URL + "/am"
+}
+
+// Requests returns the method, path below /json and query of every request
+// served so far, such as "POST /realms/root/authenticate?".
+func (a *AM) Requests() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.requests...)
+}
+
+func (a *AM) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(r.URL.Path, "/am/json")
+	a.mu.Lock()
+	a.requests = append(a.requests, r.Method+" "+p+"?"+r.URL.RawQuery)
+	a.mu.Unlock()
+
+	if a.Handle != nil && a.Handle(w, r, p) {
+		return
+	}
+
+	switch {
+	case p == "/realms/root/authenticate":
+		fmt.Fprint(w, `{"tokenId": "token"}`)
+	case strings.HasSuffix(p, "/serverinfo/*"):
+		fmt.Fprint(w, `{"cookieName": "iPlanetDirectoryPro"}`)
+	case p == "/serverinfo/version":
+		version := a.Version
+		if version == "" {
+			version = "7.2.0"
+		}
+		fmt.Fprintf(w, `{"_id": "version", "version": %q}`, version)
+	case strings.HasSuffix(p, "/sessions") && r.URL.Query().Get("_action") == "logout":
+		fmt.Fprint(w, `{"result": "Successfully logged out"}`)
+	case r.Method == http.MethodGet && a.Objects[p] != "":
+		fmt.Fprint(w, a.Objects[p])
+	default:
+		NotFound(w)
+	}
+}
+
+// NotFound answers with AM's 404 response.
+func NotFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, `{"code": 404, "reason": "Not Found", "message": "Not Found"}`)
+}
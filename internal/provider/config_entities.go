@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The resources that apply a whole export, fram_config_import and
+// fram_amster_import, share the bookkeeping below: one ConfigEntityModel per
+// entity, keyed by am.ConfigEntity.Key.
+
+// ConfigEntityModel records one imported entity.
+type ConfigEntityModel struct {
+	Hash     types.String `tfsdk:"hash"`
+	LiveHash types.String `tfsdk:"live_hash"`
+	Created  types.Bool   `tfsdk:"created"`
+}
+
+var configEntityAttrTypes = map[string]attr.Type{
+	"hash":      types.StringType,
+	"live_hash": types.StringType,
+	"created":   types.BoolType,
+}
+
+var configEntitiesType = types.MapType{ElemType: types.ObjectType{AttrTypes: configEntityAttrTypes}}
+
+func configEntitiesAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The imported entities, keyed by kind and ID, for example `journey/Login`, `node/PageNode/<id>` or `script/<id>`.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"hash": schema.StringAttribute{
+					Computed:    true,
+					Description: "The SHA-256 of the entity in the export. When the entity is changed in AM, it is replaced with the SHA-256 of what AM returns, so the change shows up in the plan.",
+				},
+				"live_hash": schema.StringAttribute{
+					Computed:    true,
+					Description: "The SHA-256 of the entity as AM returned it after the last apply, limited to the members the export sets.",
+				},
+				"created": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the entity did not exist before the import and is deleted with the resource.",
+				},
+			},
+		},
+	}
+}
+
+func configEntities(ctx context.Context, m types.Map) (map[string]ConfigEntityModel, diag.Diagnostics) {
+	entities := map[string]ConfigEntityModel{}
+	if m.IsNull() || m.IsUnknown() {
+		return entities, nil
+	}
+	diags := m.ElementsAs(ctx, &entities, false)
+	return entities, diags
+}
+
+func configEntitiesValue(ctx context.Context, entities map[string]ConfigEntityModel) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, configEntitiesType.ElemType, entities)
+}
+
+// plannedImportRealm returns the realm an import is planned for. A realm left
+// to the provider default is resolved and set in the plan; ok is false when
+// the configured realm is not known yet.
+func plannedImportRealm(ctx context.Context, client *FRAMClient, req resource.ModifyPlanRequest, realm *types.String, id *types.String, diags *diag.Diagnostics) (string, bool) {
+	if !realm.IsUnknown() {
+		return realm.ValueString(), true
+	}
+	var configured types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("realm"), &configured)...)
+	if !configured.IsNull() {
+		return "", false
+	}
+	*realm = types.StringValue(client.AM.Realm)
+	*id = types.StringValue(client.AM.Realm)
+	return client.AM.Realm, true
+}
+
+// planConfigEntities plans the entities of desired. Entities whose hash
+// matches prior keep their state; the others are rewritten, and what AM
+// returns for them is only known after apply.
+func planConfigEntities(desired []am.ConfigEntity, prior map[string]ConfigEntityModel) (map[string]ConfigEntityModel, error) {
+	planned := make(map[string]ConfigEntityModel, len(desired))
+	for _, e := range desired {
+		hash, err := am.ContentHash(e.Object)
+		if err != nil {
+			return nil, err
+		}
+		entity, ok := prior[e.Key()]
+		if ok && entity.Hash.ValueString() == hash {
+			planned[e.Key()] = entity
+			continue
+		}
+		created := types.BoolUnknown()
+		if ok {
+			created = entity.Created
+		}
+		planned[e.Key()] = ConfigEntityModel{Hash: types.StringValue(hash), LiveHash: types.StringUnknown(), Created: created}
+	}
+	return planned, nil
+}
+
+// applyConfigEntities writes desired to realm and returns the entities to
+// record. prior holds the entities of the previous apply: those no longer
+// desired are deleted if the import created them. On error the entities
+// written so far are returned, so they are still deleted with the resource.
+func applyConfigEntities(ctx context.Context, client *FRAMClient, realm string, desired []am.ConfigEntity, prior map[string]ConfigEntityModel, diags *diag.Diagnostics) map[string]ConfigEntityModel {
+	entities := make(map[string]ConfigEntityModel, len(prior))
+	removed := map[string]bool{}
+	for key, e := range prior {
+		entities[key] = e
+		removed[key] = true
+	}
+
+	for _, e := range desired {
+		key := e.Key()
+		delete(removed, key)
+
+		created := false
+		if p, ok := prior[key]; ok {
+			created = p.Created.ValueBool()
+		} else {
+			_, err := client.AM.GetConfigEntity(ctx, realm, e.Kind, e.ID)
+			switch {
+			case am.IsNotFound(err):
+				created = true
+			case err != nil:
+				diags.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", key, err))
+				return entities
+			}
+		}
+
+		live, err := client.AM.PutConfigEntity(ctx, realm, e)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to write %s, got error: %s", key, err))
+			return entities
+		}
+		hash, err := am.ContentHash(e.Object)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to hash %s, got error: %s", key, err))
+			return entities
+		}
+		liveHash, err := am.ProjectedHash(live, e.Object)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to hash %s, got error: %s", key, err))
+			return entities
+		}
+		entities[key] = ConfigEntityModel{
+			Hash:     types.StringValue(hash),
+			LiveHash: types.StringValue(liveHash),
+			Created:  types.BoolValue(created),
+		}
+	}
+
+	obsolete := map[string]ConfigEntityModel{}
+	for key := range removed {
+		obsolete[key] = entities[key]
+		delete(entities, key)
+	}
+	diags.Append(deleteConfigEntities(ctx, client, realm, obsolete)...)
+	return entities
+}
+
+// refreshConfigEntities reads the recorded entities of desired back from
+// realm. An entity that changed since the last apply gets the hash of its
+// live content, so the plan shows it; an entity that was deleted is dropped.
+func refreshConfigEntities(ctx context.Context, client *FRAMClient, realm string, desired []am.ConfigEntity, entities map[string]ConfigEntityModel, diags *diag.Diagnostics) {
+	for _, e := range desired {
+		entity, ok := entities[e.Key()]
+		if !ok {
+			continue
+		}
+		live, err := client.AM.GetConfigEntity(ctx, realm, e.Kind, e.ID)
+		if am.IsNotFound(err) {
+			delete(entities, e.Key())
+			continue
+		}
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read %s, got error: %s", e.Key(), err))
+			return
+		}
+		liveHash, err := am.ProjectedHash(live, e.Object)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to hash %s, got error: %s", e.Key(), err))
+			return
+		}
+		if liveHash != entity.LiveHash.ValueString() {
+			entity.Hash = types.StringValue(liveHash)
+			entity.LiveHash = types.StringValue(liveHash)
+			entities[e.Key()] = entity
+		}
+	}
+}
+
+// deleteConfigEntities deletes the entities the import created, in the
+// reverse of the order they are written in. An entity that is still
+// referenced, such as a node used by a Page node, is retried once after the
+// others.
+func deleteConfigEntities(ctx context.Context, client *FRAMClient, realm string, entities map[string]ConfigEntityModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var keys []string
+	for key, e := range entities {
+		if e.Created.ValueBool() {
+			keys = append(keys, key)
+		}
+	}
+	am.SortConfigEntityKeys(keys)
+
+	for attempt := 0; len(keys) > 0; attempt++ {
+		var failed []string
+		errs := map[string]error{}
+		for i := len(keys) - 1; i >= 0; i-- {
+			kind, id, err := am.ParseConfigEntityKey(keys[i])
+			if err != nil {
+				diags.AddError("Client Error", err.Error())
+				continue
+			}
+			if err := client.AM.DeleteConfigEntity(ctx, realm, kind, id); err != nil {
+				failed = append([]string{keys[i]}, failed...)
+				errs[keys[i]] = err
+			}
+		}
+		if attempt == 1 {
+			for _, key := range failed {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", key, errs[key]))
+			}
+			break
+		}
+		keys = failed
+	}
+	return diags
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringSetValue converts values into a set, mapping a nil slice to an empty
+// set so that an API omitting the attribute does not read back as null.
+func stringSetValue(ctx context.Context, values []string) (types.Set, diag.Diagnostics) {
+	if values == nil {
+		values = []string{}
+	}
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+// stringListValue is the list counterpart of stringSetValue, for values whose
+// order matters.
+func stringListValue(ctx context.Context, values []string) (types.List, diag.Diagnostics) {
+	if values == nil {
+		values = []string{}
+	}
+	return types.ListValueFrom(ctx, types.StringType, values)
+}
+
+// stringSetElementsAs converts a set of strings into a slice, mapping a null
+// set to an empty slice.
+func stringSetElementsAs(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	values := []string{}
+	if set.IsNull() || set.IsUnknown() {
+		return values, nil
+	}
+	diags := set.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// stringValueOrNull maps an empty API string to null so that optional
+// attributes left out of the configuration do not show a diff.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// jsonEqual reports whether a and b encode the same JSON value.
+func jsonEqual(a string, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// compactJSON strips insignificant whitespace from raw, returning it unchanged
+// if it is not valid JSON.
+func compactJSON(raw []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
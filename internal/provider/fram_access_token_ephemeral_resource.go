@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &AccessTokenEphemeralResource{}
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+// AccessTokenEphemeralResource defines the ephemeral resource implementation.
+type AccessTokenEphemeralResource struct {
+	client *FRAMClient
+}
+
+// AccessTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AccessTokenEphemeralResourceModel struct {
+	Realm         types.String    `tfsdk:"realm"`
+	ClientID      types.String    `tfsdk:"client_id"`
+	ClientSecret  types.String    `tfsdk:"client_secret"`
+	Scopes        types.Set       `tfsdk:"scopes"`
+	JWTBearer     *JWTBearerModel `tfsdk:"jwt_bearer"`
+	AccessToken   types.String    `tfsdk:"access_token"`
+	TokenType     types.String    `tfsdk:"token_type"`
+	ExpiresIn     types.Int64     `tfsdk:"expires_in"`
+	ExpiresAt     types.String    `tfsdk:"expires_at"`
+	GrantedScopes types.Set       `tfsdk:"granted_scopes"`
+}
+
+// JWTBearerModel describes the assertion of a JWT bearer grant.
+type JWTBearerModel struct {
+	Assertion     types.String `tfsdk:"assertion"`
+	PrivateKeyPEM types.String `tfsdk:"private_key_pem"`
+	KeyID         types.String `tfsdk:"key_id"`
+	Issuer        types.String `tfsdk:"issuer"`
+	Subject       types.String `tfsdk:"subject"`
+}
+
+// jwtBearerLifetime is how long a signed assertion is valid for; it only
+// needs to survive the token request.
+const jwtBearerLifetime = 5 * time.Minute
+
+func (r *AccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Issues an OAuth 2.0 access token from the token endpoint of a realm, using the client credentials grant or, when `jwt_bearer` is set, the JWT bearer grant (RFC 7523). " +
+			"The token is never written to the plan or state, so it can configure other providers with short-lived credentials. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm of the OAuth 2.0 client, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"client_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the OAuth 2.0 client.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The secret of the client, sent with HTTP Basic authentication. Required for the client credentials grant.",
+			},
+			"scopes": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The scopes to request. Defaults to the default scopes of the client.",
+			},
+			"jwt_bearer": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Use the JWT bearer grant. Set either `assertion` or `private_key_pem` and `issuer`.",
+				Attributes: map[string]schema.Attribute{
+					"assertion": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "A signed JWT to exchange as it is.",
+					},
+					"private_key_pem": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "A PEM encoded RSA, EC or Ed25519 private key to sign an assertion with. The assertion is audienced to the token endpoint and valid for five minutes.",
+					},
+					"key_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The `kid` header of the signed assertion, matching the key registered for the issuer.",
+					},
+					"issuer": schema.StringAttribute{
+						Optional:    true,
+						Description: "The `iss` claim of the signed assertion, as configured in the trusted JWT issuer.",
+					},
+					"subject": schema.StringAttribute{
+						Optional:    true,
+						Description: "The `sub` claim of the signed assertion. Defaults to `issuer`.",
+					},
+				},
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The token type, usually `Bearer`.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The lifetime of the token in seconds.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 time at which the token expires.",
+			},
+			"granted_scopes": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The scopes the token was issued with.",
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data AccessTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.JWTBearer == nil {
+		if data.ClientSecret.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("client_secret"), "Missing Client Secret", "The client credentials grant requires client_secret.")
+		}
+		return
+	}
+
+	assertion, key := data.JWTBearer.Assertion, data.JWTBearer.PrivateKeyPEM
+	if assertion.IsUnknown() || key.IsUnknown() {
+		return
+	}
+	if assertion.IsNull() == key.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("jwt_bearer"), "Invalid JWT Bearer Grant", "Exactly one of assertion and private_key_pem must be set.")
+	}
+	if !key.IsNull() && data.JWTBearer.Issuer.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("jwt_bearer").AtName("issuer"), "Missing Issuer", "Signing an assertion requires issuer.")
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AccessTokenEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(r.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	form := url.Values{}
+	if !data.Scopes.IsNull() {
+		scopes, diags := stringSetElementsAs(ctx, data.Scopes)
+		resp.Diagnostics.Append(diags...)
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	if data.JWTBearer == nil {
+		form.Set("grant_type", "client_credentials")
+	} else {
+		assertion, err := r.assertion(realm, data.JWTBearer)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("jwt_bearer"), "Invalid JWT Bearer Grant", fmt.Sprintf("Unable to sign the assertion, got error: %s", err))
+			return
+		}
+		form.Set("grant_type", am.JWTBearerGrantType)
+		form.Set("assertion", assertion)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuedAt := time.Now()
+	token, err := r.client.AM.RequestAccessToken(ctx, realm, data.ClientID.ValueString(), data.ClientSecret.ValueString(), form)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to issue an access token for client %q, got error: %s", data.ClientID.ValueString(), err))
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.TokenType = types.StringValue(token.TokenType)
+	data.ExpiresIn = types.Int64Value(token.ExpiresIn)
+	data.ExpiresAt = types.StringValue(issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second).UTC().Format(time.RFC3339))
+	data.GrantedScopes, diags = stringSetValue(ctx, strings.Fields(token.Scope))
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "opened an access token ephemeral resource")
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// assertion returns the configured assertion, or signs one audienced to the
+// token endpoint of realm.
+func (r *AccessTokenEphemeralResource) assertion(realm string, m *JWTBearerModel) (string, error) {
+	if !m.Assertion.IsNull() {
+		return m.Assertion.ValueString(), nil
+	}
+
+	subject := m.Subject.ValueString()
+	if subject == "" {
+		subject = m.Issuer.ValueString()
+	}
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	audience, err := r.client.AM.OAuth2URL(realm, "/access_token")
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+
+	claims := map[string]interface{}{
+		"iss": m.Issuer.ValueString(),
+		"sub": subject,
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(jwtBearerLifetime).Unix(),
+		"jti": hex.EncodeToString(jti),
+	}
+	return am.SignJWT(claims, []byte(m.PrivateKeyPEM.ValueString()), m.KeyID.ValueString())
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AmsterExportDataSource{}
+
+func NewAmsterExportDataSource() datasource.DataSource {
+	return &AmsterExportDataSource{}
+}
+
+// AmsterExportDataSource defines the data source implementation.
+type AmsterExportDataSource struct {
+	client *FRAMClient
+}
+
+// AmsterExportDataSourceModel describes the data source data model.
+type AmsterExportDataSourceModel struct {
+	Realm     types.String `tfsdk:"realm"`
+	Directory types.String `tfsdk:"directory"`
+	Files     types.Map    `tfsdk:"files"`
+}
+
+func (d *AmsterExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_amster_export"
+}
+
+func (d *AmsterExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the configuration of a realm as Amster entity files, as `export-config` writes them, so teams that keep Amster JSON under version control can compare it with AM or seed `fram_amster_import`. " +
+			"Services, journeys, nodes, scripts, OAuth 2.0 clients, policy sets, policies and resource types are rendered; volatile metadata such as `_rev` is left out.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to export, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"directory": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The directory Amster keeps the realm in, relative to the export path, for example `realms/root-alpha`.",
+			},
+			"files": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The content of each entity file, keyed by its path relative to `directory`, `<entity type>/<entity id>.json`.",
+			},
+		},
+	}
+}
+
+func (d *AmsterExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AmsterExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AmsterExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export, err := d.client.AM.ExportRealm(ctx, realm, am.AmsterSections)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export realm %q, got error: %s", realm, err))
+		return
+	}
+
+	amsterVersion := ""
+	if version, err := d.client.AM.Version(ctx); err == nil {
+		amsterVersion = version.String()
+	}
+
+	files, err := am.AmsterFiles(realm, amsterVersion, export)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to render realm %q as Amster files, got error: %s", realm, err))
+		return
+	}
+
+	contents := make(map[string]string, len(files))
+	for name, f := range files {
+		b, err := f.Encode()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode %s, got error: %s", name, err))
+			return
+		}
+		contents[name] = string(b)
+	}
+
+	directory, err := am.AmsterRealmDirectory(realm)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("realm"), "Invalid Realm", err.Error())
+		return
+	}
+	data.Directory = types.StringValue(directory)
+	data.Files, diags = types.MapValueFrom(ctx, types.StringType, contents)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read an Amster export data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AmsterImportResource{}
+var _ resource.ResourceWithModifyPlan = &AmsterImportResource{}
+
+func NewAmsterImportResource() resource.Resource {
+	return &AmsterImportResource{}
+}
+
+// AmsterImportResource defines the resource implementation.
+type AmsterImportResource struct {
+	client *FRAMClient
+}
+
+// AmsterImportModel describes the resource data model.
+type AmsterImportModel struct {
+	ID       types.String `tfsdk:"id"`
+	Realm    types.String `tfsdk:"realm"`
+	Files    types.Map    `tfsdk:"files"`
+	Entities types.Map    `tfsdk:"entities"`
+}
+
+func (r *AmsterImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_amster_import"
+}
+
+func (r *AmsterImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies a directory of Amster entity files, as written by `export-config` or `fram_amster_export`, so realms kept as Amster JSON can move to Terraform gradually. " +
+			"Journeys, nodes, scripts, services, OAuth 2.0 clients, policy sets, policies and resource types are written; files of other realms or entity types are reported and skipped. " +
+			"Applying the same files again changes nothing. A content hash is kept per entity, so a change made in AM to any imported entity shows up in the plan and is reverted on apply. " +
+			"Destroying the resource only deletes the entities it created.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The realm the files are applied to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to apply the files to, for example `/alpha`. Defaults to the provider realm. Only files whose metadata names this realm are applied. Changing it creates a new resource.",
+				Validators:  []validator.String{realmValidator{}},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"files": schema.MapAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Sensitive:   true,
+				MarkdownDescription: "The content of each Amster file, keyed by its path, usually built with `fileset()` and `file()` over the realm directory of an Amster export. " +
+					"Amster files hold secrets such as client secrets, so the map is sensitive; it is still stored in the state.",
+			},
+			"entities": configEntitiesAttribute(),
+		},
+	}
+}
+
+func (r *AmsterImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AmsterImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AmsterImportModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, map[string]ConfigEntityModel{}, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "created an Amster import resource")
+
+	// Save data into Terraform state, including a partial import so its
+	// entities are still deleted with the resource.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AmsterImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AmsterImportModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities, diags := configEntities(ctx, data.Entities)
+	resp.Diagnostics.Append(diags...)
+	desired, _, diags := amsterEntities(ctx, data.Files, data.Realm.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refreshConfigEntities(ctx, r.client, data.Realm.ValueString(), desired, entities, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Entities, diags = configEntitiesValue(ctx, entities)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AmsterImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AmsterImportModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := configEntities(ctx, state.Entities)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, prior, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AmsterImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AmsterImportModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities, diags := configEntities(ctx, data.Entities)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deleteConfigEntities(ctx, r.client, data.Realm.ValueString(), entities)...)
+}
+
+func (r *AmsterImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_amster_import", Minimum: am65}, &resp.Diagnostics)
+	if r.client == nil || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan, state AmsterImportModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || !mapKnown(plan.Files) {
+		return
+	}
+
+	realm, ok := plannedImportRealm(ctx, r.client, req, &plan.Realm, &plan.ID, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	desired, skipped, diags := amsterEntities(ctx, plan.Files, realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(skipped) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("files"), "Skipped Amster Files",
+			fmt.Sprintf("The files %s belong to another realm or hold entities the import cannot write, and are not applied.", strings.Join(skipped, ", ")))
+	}
+
+	prior, diags := configEntities(ctx, state.Entities)
+	resp.Diagnostics.Append(diags...)
+	planned, err := planConfigEntities(desired, prior)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("files"), "Invalid Amster Files", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Entities, diags = configEntitiesValue(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// apply writes the files of data to AM and records the entities in data.
+func (r *AmsterImportResource) apply(ctx context.Context, data *AmsterImportModel, prior map[string]ConfigEntityModel, diags *diag.Diagnostics) {
+	realm := data.Realm.ValueString()
+	if data.Realm.IsUnknown() || realm == "" {
+		realm = r.client.AM.Realm
+	}
+	data.Realm = types.StringValue(realm)
+	data.ID = types.StringValue(realm)
+
+	entities := prior
+	desired, _, d := amsterEntities(ctx, data.Files, realm)
+	diags.Append(d...)
+	if !d.HasError() {
+		entities = applyConfigEntities(ctx, r.client, realm, desired, prior, diags)
+	}
+
+	data.Entities, d = configEntitiesValue(ctx, entities)
+	diags.Append(d...)
+}
+
+func amsterEntities(ctx context.Context, m types.Map, realm string) ([]am.ConfigEntity, []string, diag.Diagnostics) {
+	var contents map[string]string
+	diags := m.ElementsAs(ctx, &contents, false)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	files := make(map[string][]byte, len(contents))
+	for name, content := range contents {
+		files[name] = []byte(content)
+	}
+	entities, skipped, err := am.ParseAmsterFiles(files, realm)
+	if err != nil {
+		diags.AddAttributeError(path.Root("files"), "Invalid Amster Files", err.Error())
+	}
+	return entities, skipped, diags
+}
+
+// mapKnown reports whether m and every element of it are known.
+func mapKnown(m types.Map) bool {
+	if m.IsUnknown() {
+		return false
+	}
+	for _, v := range m.Elements() {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
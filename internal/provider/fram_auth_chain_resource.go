@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AuthChainResource{}
+var _ resource.ResourceWithImportState = &AuthChainResource{}
+var _ resource.ResourceWithModifyPlan = &AuthChainResource{}
+
+func NewAuthChainResource() resource.Resource {
+	return &AuthChainResource{}
+}
+
+// AuthChainResource defines the resource implementation.
+type AuthChainResource struct {
+	client *FRAMClient
+}
+
+// AuthChainModel describes the resource data model.
+type AuthChainModel struct {
+	ID      types.String           `tfsdk:"id"`
+	Name    types.String           `tfsdk:"name"`
+	Modules []AuthChainModuleModel `tfsdk:"modules"`
+}
+
+// AuthChainModuleModel describes one module of an authentication chain.
+type AuthChainModuleModel struct {
+	Module   types.String `tfsdk:"module"`
+	Criteria types.String `tfsdk:"criteria"`
+	Options  types.Map    `tfsdk:"options"`
+}
+
+func (r *AuthChainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_chain"
+}
+
+func (r *AuthChainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a legacy authentication chain in the provider realm. Modules are evaluated in the order they are listed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the chain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the chain. Changing it creates a new chain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"modules": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The modules of the chain, in evaluation order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"module": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the authentication module instance, for example `fram_auth_module.example.name`.",
+						},
+						"criteria": schema.StringAttribute{
+							Required:    true,
+							Description: "The criteria of the module, one of `REQUIRED`, `SUFFICIENT`, `REQUISITE` or `OPTIONAL`.",
+							Validators: []validator.String{
+								stringOneOfValidator{values: []string{"REQUIRED", "SUFFICIENT", "REQUISITE", "OPTIONAL"}},
+							},
+						},
+						"options": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Computed:    true,
+							Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+							Description: "Module options, for example `iplanet-am-auth-shared-state-enabled = \"true\"`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AuthChainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AuthChainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AuthChainModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chain, diags := data.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.CreateAuthChain(ctx, chain)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create authentication chain, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	tflog.Trace(ctx, "created an authentication chain resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthChainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AuthChainModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetAuthChain(ctx, data.ID.ValueString())
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read authentication chain, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthChainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AuthChainModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chain, diags := data.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.UpdateAuthChain(ctx, chain)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update authentication chain, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthChainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AuthChainModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AM.DeleteAuthChain(ctx, data.ID.ValueString())
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete authentication chain, got error: %s", err))
+	}
+}
+
+func (r *AuthChainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_auth_chain", Minimum: am65, SelfManaged: true}, &resp.Diagnostics)
+}
+
+func (r *AuthChainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (m *AuthChainModel) toAPI(ctx context.Context) (am.AuthChain, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	chain := am.AuthChain{
+		ID:                     m.Name.ValueString(),
+		AuthChainConfiguration: make([]am.AuthChainEntry, 0, len(m.Modules)),
+	}
+	for _, module := range m.Modules {
+		entry := am.AuthChainEntry{
+			Module:   module.Module.ValueString(),
+			Criteria: module.Criteria.ValueString(),
+			Options:  map[string]string{},
+		}
+		if !module.Options.IsNull() && !module.Options.IsUnknown() {
+			diags.Append(module.Options.ElementsAs(ctx, &entry.Options, false)...)
+		}
+		chain.AuthChainConfiguration = append(chain.AuthChainConfiguration, entry)
+	}
+
+	return chain, diags
+}
+
+func (m *AuthChainModel) fromAPI(ctx context.Context, chain *am.AuthChain) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(chain.ID)
+	m.Name = types.StringValue(chain.ID)
+	m.Modules = make([]AuthChainModuleModel, 0, len(chain.AuthChainConfiguration))
+	for _, entry := range chain.AuthChainConfiguration {
+		options := entry.Options
+		if options == nil {
+			options = map[string]string{}
+		}
+		value, d := types.MapValueFrom(ctx, types.StringType, options)
+		diags.Append(d...)
+		m.Modules = append(m.Modules, AuthChainModuleModel{
+			Module:   types.StringValue(entry.Module),
+			Criteria: types.StringValue(entry.Criteria),
+			Options:  value,
+		})
+	}
+
+	return diags
+}
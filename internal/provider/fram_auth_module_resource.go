@@ -0,0 +1,318 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AuthModuleResource{}
+var _ resource.ResourceWithImportState = &AuthModuleResource{}
+var _ resource.ResourceWithModifyPlan = &AuthModuleResource{}
+
+func NewAuthModuleResource() resource.Resource {
+	return &AuthModuleResource{}
+}
+
+// AuthModuleResource defines the resource implementation.
+type AuthModuleResource struct {
+	client *FRAMClient
+}
+
+// AuthModuleModel describes the resource data model.
+type AuthModuleModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Type                types.String `tfsdk:"type"`
+	Name                types.String `tfsdk:"name"`
+	AuthenticationLevel types.Int64  `tfsdk:"authentication_level"`
+	Settings            types.Map    `tfsdk:"settings"`
+}
+
+func (r *AuthModuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_module"
+}
+
+func (r *AuthModuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a legacy authentication module in the provider realm, as used by authentication chains in " +
+			"[AM 6.5](https://backstage.forgerock.com/docs/am/6.5/authentication-guide/index.html#configure-authn-modules).",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The module type and name separated by a slash, for example `ldap/Corporate`. Use this form to import a module.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The module type, one of `" + strings.Join(am.AuthModuleTypes, "`, `") + "`. Changing it creates a new module.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringOneOfValidator{values: am.AuthModuleTypes},
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the module instance, as referenced from authentication chains. Changing it creates a new module.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"authentication_level": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The authentication level granted to users who authenticate with this module.",
+			},
+			"settings": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				MarkdownDescription: "Type specific module attributes keyed by their AM name, each encoded with `jsonencode`, for example " +
+					"`primaryLdapServer = jsonencode([\"ldap.example.com:389\"])`. Only the attributes listed here are checked for drift; " +
+					"importing a module lists all of its attributes. The map is sensitive, as it holds bind passwords and client secrets.",
+			},
+		},
+	}
+}
+
+func (r *AuthModuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AuthModuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AuthModuleModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	module, diags := data.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.CreateAuthModule(ctx, module)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create authentication module, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	tflog.Trace(ctx, "created an authentication module resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthModuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AuthModuleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetAuthModule(ctx, data.Type.ValueString(), data.Name.ValueString())
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read authentication module, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthModuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AuthModuleModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	module, diags := data.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.UpdateAuthModule(ctx, module)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update authentication module, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthModuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AuthModuleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AM.DeleteAuthModule(ctx, data.Type.ValueString(), data.Name.ValueString())
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete authentication module, got error: %s", err))
+	}
+}
+
+func (r *AuthModuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_auth_module", Minimum: am65, SelfManaged: true}, &resp.Diagnostics)
+}
+
+func (r *AuthModuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	moduleType, name, ok := strings.Cut(req.ID, "/")
+	if !ok || moduleType == "" || name == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected an identifier of the form type/name, got: %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), moduleType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+
+	// Read only refreshes the settings already in the state, so every
+	// attribute of the module is tracked from the start; otherwise the
+	// imported module would have no settings at all.
+	module, err := r.client.AM.GetAuthModule(ctx, moduleType, name)
+	if am.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read authentication module, got error: %s", err))
+		return
+	}
+	settings := map[string]string{}
+	for k, raw := range module.Attributes {
+		if k != "authenticationLevel" {
+			settings[k] = string(raw)
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("settings"), settings)...)
+}
+
+func (m *AuthModuleModel) toAPI(ctx context.Context) (am.AuthModule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	module := am.AuthModule{
+		ID:         m.Name.ValueString(),
+		Type:       m.Type.ValueString(),
+		Attributes: map[string]json.RawMessage{},
+	}
+
+	if !m.Settings.IsNull() && !m.Settings.IsUnknown() {
+		var settings map[string]string
+		diags.Append(m.Settings.ElementsAs(ctx, &settings, false)...)
+		for k, v := range settings {
+			if !json.Valid([]byte(v)) {
+				diags.AddAttributeError(path.Root("settings").AtMapKey(k), "Invalid JSON", "Module settings must be encoded with jsonencode.")
+				continue
+			}
+			module.Attributes[k] = json.RawMessage(v)
+		}
+	}
+	if !m.AuthenticationLevel.IsNull() && !m.AuthenticationLevel.IsUnknown() {
+		module.Attributes["authenticationLevel"] = json.RawMessage(fmt.Sprint(m.AuthenticationLevel.ValueInt64()))
+	}
+
+	return module, diags
+}
+
+// fromAPI refreshes the model from AM. Only settings already tracked in the
+// model are refreshed, and values that are semantically equal to the prior
+// ones are kept as written so formatting never shows up as drift.
+func (m *AuthModuleModel) fromAPI(ctx context.Context, module *am.AuthModule) diag.Diagnostics {
+	m.ID = types.StringValue(module.Type + "/" + module.ID)
+	m.Type = types.StringValue(module.Type)
+	m.Name = types.StringValue(module.ID)
+
+	m.AuthenticationLevel = types.Int64Null()
+	if raw, ok := module.Attributes["authenticationLevel"]; ok {
+		var level int64
+		if err := json.Unmarshal(raw, &level); err == nil {
+			m.AuthenticationLevel = types.Int64Value(level)
+		}
+	}
+
+	if m.Settings.IsNull() || m.Settings.IsUnknown() {
+		m.Settings = types.MapNull(types.StringType)
+		return nil
+	}
+
+	settings := map[string]attr.Value{}
+	for k, prior := range m.Settings.Elements() {
+		raw, ok := module.Attributes[k]
+		if !ok {
+			continue
+		}
+		if s, ok := prior.(types.String); ok && jsonEqual(s.ValueString(), raw) {
+			settings[k] = s
+			continue
+		}
+		settings[k] = types.StringValue(string(raw))
+	}
+
+	var diags diag.Diagnostics
+	m.Settings, diags = types.MapValue(types.StringType, settings)
+	return diags
+}
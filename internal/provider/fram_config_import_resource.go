@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigImportResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigImportResource{}
+
+func NewConfigImportResource() resource.Resource {
+	return &ConfigImportResource{}
+}
+
+// ConfigImportResource defines the resource implementation.
+type ConfigImportResource struct {
+	client *FRAMClient
+}
+
+// ConfigImportModel describes the resource data model.
+type ConfigImportModel struct {
+	ID       types.String `tfsdk:"id"`
+	Realm    types.String `tfsdk:"realm"`
+	Content  types.String `tfsdk:"content"`
+	Entities types.Map    `tfsdk:"entities"`
+}
+
+func (r *ConfigImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_import"
+}
+
+func (r *ConfigImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies a Frodo export, either a per-type export such as `frodo journey export` or a full realm export, so an existing estate can be brought under Terraform in one step. " +
+			"Journeys with their nodes and scripts, scripts, services, OAuth 2.0 clients, policy sets, policies and resource types are written; other sections of the export are reported and skipped. " +
+			"A content hash is kept per entity, so a change made in AM to any imported entity shows up in the plan and is reverted on apply. " +
+			"Destroying the resource only deletes the entities it created; entities that already existed are left as they are.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The realm the export is applied to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to apply the export to, for example `/alpha`. Defaults to the provider realm. For a full export that covers several realms, the entities of this realm are applied. Changing it creates a new resource.",
+				Validators:  []validator.String{realmValidator{}},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				MarkdownDescription: "The content of the export file, for example `file(\"alpha.json\")`. " +
+					"Exports hold secrets such as client secrets, so the content is sensitive; it is still stored in the state.",
+			},
+			"entities": configEntitiesAttribute(),
+		},
+	}
+}
+
+func (r *ConfigImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ConfigImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConfigImportModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, map[string]ConfigEntityModel{}, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "created a config import resource")
+
+	// Save data into Terraform state, including a partial import so its
+	// entities are still deleted with the resource.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ConfigImportModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities, diags := configEntities(ctx, data.Entities)
+	resp.Diagnostics.Append(diags...)
+	desired, _, err := am.ParseFrodoConfig([]byte(data.Content.ValueString()), data.Realm.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Export", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refreshConfigEntities(ctx, r.client, data.Realm.ValueString(), desired, entities, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Entities, diags = configEntitiesValue(ctx, entities)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ConfigImportModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := configEntities(ctx, state.Entities)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, prior, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ConfigImportModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entities, diags := configEntities(ctx, data.Entities)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deleteConfigEntities(ctx, r.client, data.Realm.ValueString(), entities)...)
+}
+
+func (r *ConfigImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_config_import", Minimum: am65}, &resp.Diagnostics)
+	if r.client == nil || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan, state ConfigImportModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || plan.Content.IsUnknown() {
+		return
+	}
+
+	realm, ok := plannedImportRealm(ctx, r.client, req, &plan.Realm, &plan.ID, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	desired, skipped, err := am.ParseFrodoConfig([]byte(plan.Content.ValueString()), realm)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Export", err.Error())
+		return
+	}
+	if len(skipped) > 0 {
+		resp.Diagnostics.AddAttributeWarning(path.Root("content"), "Unsupported Export Sections",
+			fmt.Sprintf("The sections %s of the export are not applied.", strings.Join(skipped, ", ")))
+	}
+
+	prior, diags := configEntities(ctx, state.Entities)
+	resp.Diagnostics.Append(diags...)
+	planned, err := planConfigEntities(desired, prior)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Export", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Entities, diags = configEntitiesValue(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// apply writes the export of data to AM and records the entities in data.
+func (r *ConfigImportResource) apply(ctx context.Context, data *ConfigImportModel, prior map[string]ConfigEntityModel, diags *diag.Diagnostics) {
+	realm := data.Realm.ValueString()
+	if data.Realm.IsUnknown() || realm == "" {
+		realm = r.client.AM.Realm
+	}
+	data.Realm = types.StringValue(realm)
+	data.ID = types.StringValue(realm)
+
+	entities := prior
+	desired, _, err := am.ParseFrodoConfig([]byte(data.Content.ValueString()), realm)
+	if err != nil {
+		diags.AddAttributeError(path.Root("content"), "Invalid Export", err.Error())
+	} else {
+		entities = applyConfigEntities(ctx, r.client, realm, desired, prior, diags)
+	}
+
+	var d diag.Diagnostics
+	data.Entities, d = configEntitiesValue(ctx, entities)
+	diags.Append(d...)
+}
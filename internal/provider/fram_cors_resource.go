@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CorsResource{}
+var _ resource.ResourceWithImportState = &CorsResource{}
+var _ resource.ResourceWithModifyPlan = &CorsResource{}
+
+func NewCorsResource() resource.Resource {
+	return &CorsResource{}
+}
+
+// CorsResource defines the resource implementation.
+type CorsResource struct {
+	client *FRAMClient
+}
+
+// CorsModel describes the resource data model.
+type CorsModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	AcceptedOrigins  types.Set    `tfsdk:"accepted_origins"`
+	AcceptedMethods  types.Set    `tfsdk:"accepted_methods"`
+	AcceptedHeaders  types.Set    `tfsdk:"accepted_headers"`
+	ExposedHeaders   types.Set    `tfsdk:"exposed_headers"`
+	MaxAge           types.Int64  `tfsdk:"max_age"`
+	AllowCredentials types.Bool   `tfsdk:"allow_credentials"`
+}
+
+func (r *CorsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cors"
+}
+
+func (r *CorsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	emptySet := setdefault.StaticValue(types.SetValueMust(types.StringType, nil))
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a named configuration of the global CORS service. AM 7 keeps the allowed origins, methods and headers in these " +
+			"configurations, and applies them while the CORS service itself is enabled.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the configuration. Use it to import a configuration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the configuration. Changing it creates a new configuration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether AM applies this configuration.",
+			},
+			"accepted_origins": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Origins allowed to make cross-origin requests, for example `https://app.example.com`, or `*` for any origin.",
+				Validators: []validator.Set{
+					originSetValidator{},
+				},
+			},
+			"accepted_methods": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     emptySet,
+				Description: "HTTP methods allowed in cross-origin requests.",
+			},
+			"accepted_headers": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     emptySet,
+				Description: "Request headers allowed in cross-origin requests.",
+			},
+			"exposed_headers": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     emptySet,
+				Description: "Response headers the browser may expose to the calling script.",
+			},
+			"max_age": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(600),
+				Description: "Time in seconds a browser may cache the result of a preflight request.",
+			},
+			"allow_credentials": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether cross-origin requests may include credentials such as cookies.",
+			},
+		},
+	}
+}
+
+func (r *CorsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CorsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CorsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a cors resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CorsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CorsModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetCorsConfiguration(ctx, data.ID.ValueString())
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read CORS configuration, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CorsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CorsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CorsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CorsModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AM.DeleteCorsConfiguration(ctx, data.ID.ValueString())
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete CORS configuration, got error: %s", err))
+	}
+}
+
+func (r *CorsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_cors", Minimum: am70}, &resp.Diagnostics)
+}
+
+func (r *CorsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *CorsResource) write(ctx context.Context, data *CorsModel, diags *diag.Diagnostics) {
+	cors := am.CorsConfiguration{ID: data.Name.ValueString()}
+	var d diag.Diagnostics
+
+	cors.AcceptedOrigins, d = stringSetElementsAs(ctx, data.AcceptedOrigins)
+	diags.Append(d...)
+	cors.AcceptedMethods, d = stringSetElementsAs(ctx, data.AcceptedMethods)
+	diags.Append(d...)
+	cors.AcceptedHeaders, d = stringSetElementsAs(ctx, data.AcceptedHeaders)
+	diags.Append(d...)
+	cors.ExposedHeaders, d = stringSetElementsAs(ctx, data.ExposedHeaders)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	cors.Enabled = data.Enabled.ValueBool()
+	cors.MaxAge = data.MaxAge.ValueInt64()
+	cors.AllowCredentials = data.AllowCredentials.ValueBool()
+
+	result, err := r.client.AM.PutCorsConfiguration(ctx, cors)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to write CORS configuration, got error: %s", err))
+		return
+	}
+
+	diags.Append(data.fromAPI(ctx, result)...)
+
+	// The configuration is ignored while the service is off, which is easy to
+	// miss as nothing fails.
+	if service, err := r.client.AM.GetCorsService(ctx); err == nil && !service.Enabled {
+		diags.AddWarning("CORS Service Disabled",
+			fmt.Sprintf("The CORS service is disabled, so AM does not apply the %s configuration until it is enabled.", cors.ID))
+	}
+}
+
+func (m *CorsModel) fromAPI(ctx context.Context, cors *am.CorsConfiguration) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	m.ID = types.StringValue(cors.ID)
+	m.Name = types.StringValue(cors.ID)
+	m.Enabled = types.BoolValue(cors.Enabled)
+	m.MaxAge = types.Int64Value(cors.MaxAge)
+	m.AllowCredentials = types.BoolValue(cors.AllowCredentials)
+	m.AcceptedOrigins, d = stringSetValue(ctx, cors.AcceptedOrigins)
+	diags.Append(d...)
+	m.AcceptedMethods, d = stringSetValue(ctx, cors.AcceptedMethods)
+	diags.Append(d...)
+	m.AcceptedHeaders, d = stringSetValue(ctx, cors.AcceptedHeaders)
+	diags.Append(d...)
+	m.ExposedHeaders, d = stringSetValue(ctx, cors.ExposedHeaders)
+	diags.Append(d...)
+
+	return diags
+}
@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/darkedges/fram-client-go/fram"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,7 +21,7 @@ func NewBaseURLSourceDataSource() datasource.DataSource {
 
 // BaseURLSourceDataSource defines the data source implementation.
 type BaseURLSourceDataSource struct {
-	client *fram.Client
+	client *FRAMClient
 }
 
 // BaseURLSourceDataSourceModel describes the data source data model.
@@ -34,7 +33,7 @@ type BaseURLSourceDataSourceModel struct {
 }
 
 func (d *BaseURLSourceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
-	resp.TypeName = "fram_" + req.ProviderTypeName
+	resp.TypeName = req.ProviderTypeName + "_baseurlsource"
 }
 
 func (d *BaseURLSourceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -79,12 +78,12 @@ func (d *BaseURLSourceDataSource) Configure(ctx context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*fram.Client)
+	client, ok := req.ProviderData.(*FRAMClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -103,6 +102,11 @@ func (d *BaseURLSourceDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
+	checkBaseURLSourceAuth(d.client, "fram_baseurlsource", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bus, err := d.client.GetBaseURLSource()
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupResource{}
+var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
+
+func NewGroupResource() resource.Resource {
+	return &GroupResource{}
+}
+
+// GroupResource defines the resource implementation.
+type GroupResource struct {
+	client *FRAMClient
+}
+
+// GroupModel describes the resource data model.
+type GroupModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Members    types.Set    `tfsdk:"members"`
+	Privileges types.Set    `tfsdk:"privileges"`
+}
+
+func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	emptySet := setdefault.StaticValue(types.SetValueMust(types.StringType, nil))
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a group, its members and its realm privileges in the identity store of the provider realm.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the group. Changing it creates a new group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     emptySet,
+				Description: "The IDs of the users that belong to the group, for example `fram_user.example.id`.",
+			},
+			"privileges": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     emptySet,
+				Description: "The realm privileges granted to the group, for example `RealmAdmin` or `PolicyAdmin`.",
+			},
+		},
+	}
+}
+
+func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := r.toAPI(ctx, &data, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.CreateGroup(ctx, group)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create group, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	tflog.Trace(ctx, "created a group resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetGroup(ctx, data.ID.ValueString())
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state GroupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group := r.toAPI(ctx, &data, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.UpdateGroup(ctx, data.ID.ValueString(), group)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update group, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AM.DeleteGroup(ctx, data.ID.ValueString())
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete group, got error: %s", err))
+	}
+}
+
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_group", Minimum: am65}, &resp.Diagnostics)
+}
+
+func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// toAPI builds the request body. AM stores members as DNs, so each user ID is
+// resolved to its DN, and privileges removed since the prior state are
+// explicitly revoked.
+func (r *GroupResource) toAPI(ctx context.Context, data *GroupModel, prior *GroupModel, diags *diag.Diagnostics) am.Group {
+	group := am.Group{
+		Username:     data.Name.ValueString(),
+		UniqueMember: []string{},
+		Privileges:   map[string]bool{},
+	}
+
+	members, d := stringSetElementsAs(ctx, data.Members)
+	diags.Append(d...)
+	for _, id := range members {
+		user, err := r.client.AM.GetUser(ctx, id)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to resolve group member %q, got error: %s", id, err))
+			continue
+		}
+		if user.DN == "" {
+			diags.AddAttributeError(path.Root("members"), "Unresolved Group Member",
+				fmt.Sprintf("AM returned no DN for user %q, so it cannot be added to the group.", id))
+			continue
+		}
+		group.UniqueMember = append(group.UniqueMember, user.DN)
+	}
+
+	if prior != nil {
+		revoked, d := stringSetElementsAs(ctx, prior.Privileges)
+		diags.Append(d...)
+		for _, p := range revoked {
+			group.Privileges[p] = false
+		}
+	}
+	granted, d := stringSetElementsAs(ctx, data.Privileges)
+	diags.Append(d...)
+	for _, p := range granted {
+		group.Privileges[p] = true
+	}
+
+	return group
+}
+
+func (m *GroupModel) fromAPI(ctx context.Context, group *am.Group) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	members := make([]string, 0, len(group.UniqueMember))
+	for _, dn := range group.UniqueMember {
+		members = append(members, am.FirstRDNValue(dn))
+	}
+
+	privileges := []string{}
+	for name, granted := range group.Privileges {
+		if granted {
+			privileges = append(privileges, name)
+		}
+	}
+
+	m.ID = types.StringValue(group.ID)
+	if group.ID == "" {
+		m.ID = types.StringValue(group.Username)
+	}
+	m.Name = types.StringValue(group.Username)
+	m.Members, d = stringSetValue(ctx, members)
+	diags.Append(d...)
+	m.Privileges, d = stringSetValue(ctx, privileges)
+	diags.Append(d...)
+
+	return diags
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IdentitiesDataSource{}
+
+func NewIdentitiesDataSource() datasource.DataSource {
+	return &IdentitiesDataSource{}
+}
+
+// IdentitiesDataSource defines the data source implementation.
+type IdentitiesDataSource struct {
+	client *FRAMClient
+}
+
+// IdentitiesDataSourceModel describes the data source data model.
+type IdentitiesDataSourceModel struct {
+	Realm       types.String    `tfsdk:"realm"`
+	Type        types.String    `tfsdk:"type"`
+	QueryFilter types.String    `tfsdk:"query_filter"`
+	Fields      types.List      `tfsdk:"fields"`
+	SortKeys    types.List      `tfsdk:"sort_keys"`
+	Limit       types.Int64     `tfsdk:"limit"`
+	Identities  []IdentityModel `tfsdk:"identities"`
+}
+
+// IdentityModel describes one user or group of the data source.
+type IdentityModel struct {
+	ID         types.String `tfsdk:"id"`
+	Username   types.String `tfsdk:"username"`
+	Attributes types.Map    `tfsdk:"attributes"`
+}
+
+func (d *IdentitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identities"
+}
+
+func (d *IdentitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the users or groups of a realm that match a CREST query, for example to look up the members of a group or to feed an access review. " +
+			"Pages are read until `limit` results have been returned. Sensitive attributes (`" + strings.Join(am.SensitiveIdentityAttributes, "`, `") + "`) are never returned, even when requested in `fields`.",
+
+		Attributes: withQueryAttributes(map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "`users` or `groups`. Defaults to `users`.",
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"users", "groups"}},
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of identities to return. Defaults to every match.",
+			},
+			"identities": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching identities.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The identifier of the identity.",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "The username, or the name of the group.",
+						},
+						"attributes": schema.MapAttribute{
+							ElementType: stringListType,
+							Computed:    true,
+							Description: "The other string attributes of the identity, for example `mail` or `uniquemember`.",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func (d *IdentitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IdentitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IdentitiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := queryParams(ctx, d.client, &data.Realm, data.QueryFilter, data.Fields, data.SortKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	params.Limit = int(data.Limit.ValueInt64())
+
+	kind := data.Type.ValueString()
+	if kind == "" {
+		kind = "users"
+	}
+
+	identities, err := d.client.AM.QueryIdentities(ctx, data.Realm.ValueString(), kind, params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to query %s, got error: %s", kind, err))
+		return
+	}
+
+	data.Identities = make([]IdentityModel, 0, len(identities))
+	for _, identity := range identities {
+		attributes, diags := types.MapValueFrom(ctx, stringListType, identity.Attributes)
+		resp.Diagnostics.Append(diags...)
+		data.Identities = append(data.Identities, IdentityModel{
+			ID:         types.StringValue(identity.ID),
+			Username:   types.StringValue(identity.Username),
+			Attributes: attributes,
+		})
+	}
+
+	tflog.Trace(ctx, "read an identities data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JourneyNodeTypesDataSource{}
+
+func NewJourneyNodeTypesDataSource() datasource.DataSource {
+	return &JourneyNodeTypesDataSource{}
+}
+
+// nodeTypeRequestLimit is the number of node types described at once.
+const nodeTypeRequestLimit = 4
+
+// JourneyNodeTypesDataSource defines the data source implementation.
+type JourneyNodeTypesDataSource struct {
+	client *FRAMClient
+}
+
+// JourneyNodeTypesDataSourceModel describes the data source data model.
+type JourneyNodeTypesDataSourceModel struct {
+	Realm     types.String           `tfsdk:"realm"`
+	Types     types.Set              `tfsdk:"types"`
+	IDs       types.Set              `tfsdk:"ids"`
+	NodeTypes []JourneyNodeTypeModel `tfsdk:"node_types"`
+}
+
+// JourneyNodeTypeModel describes one node type of the data source.
+type JourneyNodeTypeModel struct {
+	ID         types.String              `tfsdk:"id"`
+	Name       types.String              `tfsdk:"name"`
+	Collection types.Bool                `tfsdk:"collection"`
+	Tags       types.List                `tfsdk:"tags"`
+	Schema     types.String              `tfsdk:"schema"`
+	Template   types.String              `tfsdk:"template"`
+	Outcomes   []JourneyNodeOutcomeModel `tfsdk:"outcomes"`
+}
+
+// JourneyNodeOutcomeModel describes an outcome of a node type.
+type JourneyNodeOutcomeModel struct {
+	ID          types.String `tfsdk:"id"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+func (d *JourneyNodeTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_journey_node_types"
+}
+
+func (d *JourneyNodeTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the journey node types installed on the server, including custom and marketplace nodes, with their configuration schema, default configuration and outcomes. " +
+			"Only the node types listed in `types` are described, as that takes three requests per node type; without `types` every installed type is listed by name.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to read the node types of, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"types": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The node types to describe, for example `[\"UsernameCollectorNode\"]`. Reading fails if one of them is not installed, which makes it suitable for checking that a custom node is present. Without it, every installed type is listed without its schema, template and outcomes.",
+			},
+			"ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The IDs of every installed node type, regardless of `types`.",
+			},
+			"node_types": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The described node types, ordered by ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The node type ID, as used in a journey's `nodeType`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name shown in the journey editor.",
+						},
+						"collection": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the node can be placed inside a Page node.",
+						},
+						"tags": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The tags the journey editor groups the node by.",
+						},
+						"schema": schema.StringAttribute{
+							Computed:    true,
+							Description: "The JSON schema of the node configuration. Only set for the node types listed in `types`.",
+						},
+						"template": schema.StringAttribute{
+							Computed:    true,
+							Description: "The default node configuration as JSON. Only set for the node types listed in `types`.",
+						},
+						"outcomes": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The outcomes of the node with its default configuration. Only set for the node types listed in `types`.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Computed:    true,
+										Description: "The outcome ID used in journey connections.",
+									},
+									"display_name": schema.StringAttribute{
+										Computed:    true,
+										Description: "The label of the outcome.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *JourneyNodeTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *JourneyNodeTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JourneyNodeTypesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	installed, err := d.client.AM.ListNodeTypes(ctx, realm)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list node types, got error: %s", err))
+		return
+	}
+	sort.Slice(installed, func(i, j int) bool { return installed[i].ID < installed[j].ID })
+
+	byID := make(map[string]am.NodeType, len(installed))
+	ids := make([]string, 0, len(installed))
+	for _, t := range installed {
+		byID[t.ID] = t
+		ids = append(ids, t.ID)
+	}
+
+	data.IDs, diags = stringSetValue(ctx, ids)
+	resp.Diagnostics.Append(diags...)
+
+	if data.Types.IsNull() {
+		data.NodeTypes = make([]JourneyNodeTypeModel, 0, len(installed))
+		for _, t := range installed {
+			model, diags := nodeTypeModel(ctx, t)
+			resp.Diagnostics.Append(diags...)
+			data.NodeTypes = append(data.NodeTypes, model)
+		}
+	} else {
+		data.NodeTypes, diags = d.describeTypes(ctx, realm, data.Types, byID)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a journey node types data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// describeTypes describes the node types listed in typesSet, failing if one
+// of them is not installed. The node types are described a few at a time.
+func (d *JourneyNodeTypesDataSource) describeTypes(ctx context.Context, realm string, typesSet types.Set, byID map[string]am.NodeType) ([]JourneyNodeTypeModel, diag.Diagnostics) {
+	wanted, diags := stringSetElementsAs(ctx, typesSet)
+	if diags.HasError() {
+		return nil, diags
+	}
+	sort.Strings(wanted)
+
+	var missing []string
+	for _, id := range wanted {
+		if _, ok := byID[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		diags.AddAttributeError(
+			path.Root("types"),
+			"Node Type Not Installed",
+			fmt.Sprintf("The node types %s are not installed on the server. Install the node JARs before applying journeys that use them.", strings.Join(missing, ", ")),
+		)
+		return nil, diags
+	}
+
+	models := make([]JourneyNodeTypeModel, len(wanted))
+	results := make([]diag.Diagnostics, len(wanted))
+	limit := make(chan struct{}, nodeTypeRequestLimit)
+	var wg sync.WaitGroup
+	for i, id := range wanted {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			models[i], results[i] = d.describe(ctx, realm, byID[id])
+		}()
+	}
+	wg.Wait()
+	for _, result := range results {
+		diags.Append(result...)
+	}
+	return models, diags
+}
+
+// nodeTypeModel returns the model of a node type without its details.
+func nodeTypeModel(ctx context.Context, nodeType am.NodeType) (JourneyNodeTypeModel, diag.Diagnostics) {
+	model := JourneyNodeTypeModel{
+		ID:         types.StringValue(nodeType.ID),
+		Name:       types.StringValue(nodeType.Name),
+		Collection: types.BoolValue(nodeType.Collection),
+		Schema:     types.StringNull(),
+		Template:   types.StringNull(),
+	}
+	var diags diag.Diagnostics
+	model.Tags, diags = stringListValue(ctx, nodeType.Tags)
+	return model, diags
+}
+
+// describe returns the model of a node type with its schema, template and
+// outcomes.
+func (d *JourneyNodeTypesDataSource) describe(ctx context.Context, realm string, nodeType am.NodeType) (JourneyNodeTypeModel, diag.Diagnostics) {
+	model, diags := nodeTypeModel(ctx, nodeType)
+
+	nodeSchema, err := d.client.AM.GetNodeTypeSchema(ctx, realm, nodeType.ID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the schema of node type %q, got error: %s", nodeType.ID, err))
+		return model, diags
+	}
+	template, err := d.client.AM.GetNodeTypeTemplate(ctx, realm, nodeType.ID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the template of node type %q, got error: %s", nodeType.ID, err))
+		return model, diags
+	}
+	outcomes, err := d.client.AM.ListNodeOutcomes(ctx, realm, nodeType.ID, template)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list the outcomes of node type %q, got error: %s", nodeType.ID, err))
+		return model, diags
+	}
+
+	model.Schema = types.StringValue(compactJSON(nodeSchema))
+	model.Template = types.StringValue(compactJSON(template))
+	model.Outcomes = make([]JourneyNodeOutcomeModel, 0, len(outcomes))
+	for _, o := range outcomes {
+		model.Outcomes = append(model.Outcomes, JourneyNodeOutcomeModel{
+			ID:          types.StringValue(o.ID),
+			DisplayName: types.StringValue(o.DisplayName),
+		})
+	}
+
+	return model, diags
+}
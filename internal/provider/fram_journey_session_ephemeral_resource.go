@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &JourneySessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &JourneySessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &JourneySessionEphemeralResource{}
+
+func NewJourneySessionEphemeralResource() ephemeral.EphemeralResource {
+	return &JourneySessionEphemeralResource{}
+}
+
+// JourneySessionEphemeralResource defines the ephemeral resource implementation.
+type JourneySessionEphemeralResource struct {
+	client *FRAMClient
+}
+
+// JourneySessionEphemeralResourceModel describes the ephemeral resource data model.
+type JourneySessionEphemeralResourceModel struct {
+	Realm           types.String `tfsdk:"realm"`
+	Journey         types.String `tfsdk:"journey"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	Choices         types.Map    `tfsdk:"choices"`
+	OneTimePassword types.String `tfsdk:"one_time_password"`
+	TOTPSecret      types.String `tfsdk:"totp_secret"`
+	TokenID         types.String `tfsdk:"token_id"`
+	SuccessURL      types.String `tfsdk:"success_url"`
+	UniversalID     types.String `tfsdk:"universal_id"`
+	Properties      types.Map    `tfsdk:"properties"`
+}
+
+// sessionPrivateKey is the private data key that carries the session to
+// Close.
+const sessionPrivateKey = "session"
+
+// sessionPrivate is the private data of an open session.
+type sessionPrivate struct {
+	Realm   string `json:"realm"`
+	TokenID string `json:"token_id"`
+}
+
+func (r *JourneySessionEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_journey_session"
+}
+
+func (r *JourneySessionEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Signs in to a realm through a journey, for smoke tests and for handing a session to other tools. " +
+			"`NameCallback`, `PasswordCallback`, `ChoiceCallback`, `ConfirmationCallback` and one-time password prompts are answered from the configuration; text output callbacks are skipped. " +
+			"The session is logged out when Terraform closes the ephemeral resource and is never written to the plan or state. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to sign in to, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"journey": schema.StringAttribute{
+				Optional:    true,
+				Description: "The journey to run. Defaults to the default journey of the realm.",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "The answer to `NameCallback`.",
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The answer to `PasswordCallback`.",
+			},
+			"choices": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The choice to pick for each `ChoiceCallback`, keyed by its prompt. Other prompts take their default choice.",
+			},
+			"one_time_password": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "The answer to a one-time password prompt, such as the one of the OTP Collector Decision or OATH Token Verifier node. " +
+					"A fixed code is likely to have expired by the time the session is opened; use `totp_secret` for OATH devices.",
+			},
+			"totp_secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "Base32 secret, or `otpauth://totp/` URI, of the OATH device registered for `username`. " +
+					"The answer to the one-time password prompt is generated from it when the session is opened. Conflicts with `one_time_password`.",
+			},
+			"token_id": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The SSO token of the session.",
+			},
+			"success_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL the journey sends the user to on success.",
+			},
+			"universal_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The universal ID of the signed in user.",
+			},
+			"properties": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The session properties AM is configured to return.",
+			},
+		},
+	}
+}
+
+func (r *JourneySessionEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *JourneySessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data JourneySessionEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(r.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	answers := am.CallbackAnswers{
+		Username: data.Username.ValueString(),
+		Password: data.Password.ValueString(),
+		Choices:  map[string]string{},
+	}
+	if !data.Choices.IsNull() {
+		resp.Diagnostics.Append(data.Choices.ElementsAs(ctx, &answers.Choices, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	switch {
+	case !data.OneTimePassword.IsNull() && !data.TOTPSecret.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("totp_secret"), "Conflicting Attributes", "Only one of one_time_password and totp_secret can be set.")
+		return
+	case !data.OneTimePassword.IsNull():
+		code := data.OneTimePassword.ValueString()
+		answers.OneTimePassword = func() (string, error) { return code, nil }
+	case !data.TOTPSecret.IsNull():
+		secret, err := am.ParseTOTPSecret(data.TOTPSecret.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("totp_secret"), "Invalid TOTP Secret", err.Error())
+			return
+		}
+		answers.OneTimePassword = func() (string, error) { return secret.Code(ctx) }
+	}
+
+	session, err := r.client.AM.AuthenticateJourney(ctx, realm, data.Journey.ValueString(), answers)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to sign in to realm %q, got error: %s", realm, err))
+		return
+	}
+
+	// Close is not called when Open fails, so a session that cannot be
+	// handed over is logged out here.
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			return
+		}
+		if err := r.client.AM.Logout(ctx, realm, session.TokenID); err != nil {
+			resp.Diagnostics.AddWarning("Session Not Logged Out",
+				fmt.Sprintf("Unable to log out of realm %q after the failure above, got error: %s. The session stays valid until it expires.", realm, err))
+		}
+	}()
+
+	private, err := json.Marshal(sessionPrivate{Realm: realm, TokenID: session.TokenID})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to record the session, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionPrivateKey, private)...)
+
+	info, err := r.client.AM.GetSessionInfo(ctx, realm, session.TokenID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the session, got error: %s", err))
+		return
+	}
+
+	properties, diags := types.MapValueFrom(ctx, types.StringType, info.Properties)
+	resp.Diagnostics.Append(diags...)
+
+	data.TokenID = types.StringValue(session.TokenID)
+	data.SuccessURL = types.StringValue(session.SuccessURL)
+	data.UniversalID = types.StringValue(info.UniversalID)
+	data.Properties = properties
+
+	tflog.Trace(ctx, "opened a journey session ephemeral resource")
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *JourneySessionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, sessionPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var session sessionPrivate
+	if err := json.Unmarshal(raw, &session); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the session, got error: %s", err))
+		return
+	}
+
+	if err := r.client.AM.Logout(ctx, session.Realm, session.TokenID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to log out of realm %q, got error: %s", session.Realm, err))
+		return
+	}
+
+	tflog.Trace(ctx, "closed a journey session ephemeral resource")
+}
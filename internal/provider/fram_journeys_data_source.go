@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JourneysDataSource{}
+
+func NewJourneysDataSource() datasource.DataSource {
+	return &JourneysDataSource{}
+}
+
+// JourneysDataSource defines the data source implementation.
+type JourneysDataSource struct {
+	client *FRAMClient
+}
+
+// JourneysDataSourceModel describes the data source data model.
+type JourneysDataSourceModel struct {
+	Realm       types.String          `tfsdk:"realm"`
+	QueryFilter types.String          `tfsdk:"query_filter"`
+	Fields      types.List            `tfsdk:"fields"`
+	SortKeys    types.List            `tfsdk:"sort_keys"`
+	Journeys    []JourneySummaryModel `tfsdk:"journeys"`
+}
+
+// JourneySummaryModel describes one journey of the data source.
+type JourneySummaryModel struct {
+	ID               types.String          `tfsdk:"id"`
+	Description      types.String          `tfsdk:"description"`
+	Enabled          types.Bool            `tfsdk:"enabled"`
+	EntryNodeID      types.String          `tfsdk:"entry_node_id"`
+	IdentityResource types.String          `tfsdk:"identity_resource"`
+	InnerTreeOnly    types.Bool            `tfsdk:"inner_tree_only"`
+	Nodes            []JourneyNodeRefModel `tfsdk:"nodes"`
+}
+
+// JourneyNodeRefModel describes a node placed in a journey.
+type JourneyNodeRefModel struct {
+	ID          types.String `tfsdk:"id"`
+	NodeType    types.String `tfsdk:"node_type"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+func (d *JourneysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_journeys"
+}
+
+func (d *JourneysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the authentication journeys of a realm that match a CREST query, for example `enabled eq false`. AM only lists journeys in full, so the provider applies the filter, sort keys and fields itself.",
+
+		Attributes: withQueryAttributes(map[string]schema.Attribute{
+			"journeys": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching journeys.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the journey.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the journey.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the journey can be used. Always `true` before AM 7.0.",
+						},
+						"entry_node_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the first node.",
+						},
+						"identity_resource": schema.StringAttribute{
+							Computed:    true,
+							Description: "The identity resource the journey authenticates against, for example `managed/alpha_user`.",
+						},
+						"inner_tree_only": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the journey can only be run from another journey.",
+						},
+						"nodes": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The nodes of the journey, ordered by ID.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Computed:    true,
+										Description: "The node ID.",
+									},
+									"node_type": schema.StringAttribute{
+										Computed:    true,
+										Description: "The node type, for example `UsernameCollectorNode`.",
+									},
+									"display_name": schema.StringAttribute{
+										Computed:    true,
+										Description: "The label of the node.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func (d *JourneysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *JourneysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JourneysDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := queryParams(ctx, d.client, &data.Realm, data.QueryFilter, data.Fields, data.SortKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	journeys, err := d.client.AM.QueryJourneys(ctx, data.Realm.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to query journeys, got error: %s", err))
+		return
+	}
+
+	data.Journeys = make([]JourneySummaryModel, 0, len(journeys))
+	for _, j := range journeys {
+		ids := make([]string, 0, len(j.Nodes))
+		for id := range j.Nodes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		nodes := make([]JourneyNodeRefModel, 0, len(ids))
+		for _, id := range ids {
+			nodes = append(nodes, JourneyNodeRefModel{
+				ID:          types.StringValue(id),
+				NodeType:    types.StringValue(j.Nodes[id].NodeType),
+				DisplayName: types.StringValue(j.Nodes[id].DisplayName),
+			})
+		}
+
+		data.Journeys = append(data.Journeys, JourneySummaryModel{
+			ID:               types.StringValue(j.ID),
+			Description:      types.StringValue(j.Description),
+			Enabled:          types.BoolValue(j.IsEnabled()),
+			EntryNodeID:      types.StringValue(j.EntryNodeID),
+			IdentityResource: types.StringValue(j.IdentityResource),
+			InnerTreeOnly:    types.BoolValue(j.InnerTreeOnly),
+			Nodes:            nodes,
+		})
+	}
+
+	tflog.Trace(ctx, "read a journeys data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
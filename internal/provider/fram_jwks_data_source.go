@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JWKSDataSource{}
+
+func NewJWKSDataSource() datasource.DataSource {
+	return &JWKSDataSource{}
+}
+
+// JWKSDataSource defines the data source implementation.
+type JWKSDataSource struct {
+	client *FRAMClient
+}
+
+// JWKSDataSourceModel describes the data source data model.
+type JWKSDataSourceModel struct {
+	Realm types.String `tfsdk:"realm"`
+	Keys  []JWKModel   `tfsdk:"keys"`
+}
+
+// JWKModel describes one key of the set.
+type JWKModel struct {
+	KeyID             types.String `tfsdk:"kid"`
+	KeyType           types.String `tfsdk:"kty"`
+	Algorithm         types.String `tfsdk:"alg"`
+	Use               types.String `tfsdk:"use"`
+	PublicKeyPEM      types.String `tfsdk:"public_key_pem"`
+	CertificatePEM    types.String `tfsdk:"certificate_pem"`
+	CertificateExpiry types.String `tfsdk:"certificate_expiry"`
+}
+
+func (d *JWKSDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwks"
+}
+
+func (d *JWKSDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the public keys a realm publishes at `connect/jwk_uri`, for example to let another system verify tokens issued by AM.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to read, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"keys": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The keys in the order AM publishes them.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							Computed:    true,
+							Description: "The key ID.",
+						},
+						"kty": schema.StringAttribute{
+							Computed:    true,
+							Description: "The key type, for example `RSA` or `EC`.",
+						},
+						"alg": schema.StringAttribute{
+							Computed:    true,
+							Description: "The algorithm the key is used with, for example `RS256`.",
+						},
+						"use": schema.StringAttribute{
+							Computed:    true,
+							Description: "The intended use of the key, `sig` or `enc`.",
+						},
+						"public_key_pem": schema.StringAttribute{
+							Computed:    true,
+							Description: "The public key in PEM format.",
+						},
+						"certificate_pem": schema.StringAttribute{
+							Computed:    true,
+							Description: "The certificate of the key in PEM format, if AM publishes one.",
+						},
+						"certificate_expiry": schema.StringAttribute{
+							Computed:    true,
+							Description: "The RFC 3339 time at which the certificate expires, if AM publishes one.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *JWKSDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *JWKSDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JWKSDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set, err := d.client.AM.GetJWKS(ctx, realm)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read JWKS, got error: %s", err))
+		return
+	}
+
+	data.Keys = make([]JWKModel, 0, len(set.Keys))
+	for _, key := range set.Keys {
+		publicKey, err := key.PEM()
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Key", fmt.Sprintf("Unable to decode key %q, got error: %s", key.KeyID, err))
+			return
+		}
+		cert, err := key.Certificate()
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Key", fmt.Sprintf("Unable to decode certificate of key %q, got error: %s", key.KeyID, err))
+			return
+		}
+
+		model := JWKModel{
+			KeyID:             types.StringValue(key.KeyID),
+			KeyType:           types.StringValue(key.KeyType),
+			Algorithm:         stringValueOrNull(key.Algorithm),
+			Use:               stringValueOrNull(key.Use),
+			PublicKeyPEM:      types.StringValue(publicKey),
+			CertificatePEM:    types.StringNull(),
+			CertificateExpiry: types.StringNull(),
+		}
+		if cert != nil {
+			model.CertificatePEM = types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+			model.CertificateExpiry = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		data.Keys = append(data.Keys, model)
+	}
+
+	tflog.Trace(ctx, "read a JWKS data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
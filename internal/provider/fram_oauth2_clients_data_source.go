@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OAuth2ClientsDataSource{}
+
+func NewOAuth2ClientsDataSource() datasource.DataSource {
+	return &OAuth2ClientsDataSource{}
+}
+
+// OAuth2ClientsDataSource defines the data source implementation.
+type OAuth2ClientsDataSource struct {
+	client *FRAMClient
+}
+
+// OAuth2ClientsDataSourceModel describes the data source data model.
+type OAuth2ClientsDataSourceModel struct {
+	Realm       types.String        `tfsdk:"realm"`
+	QueryFilter types.String        `tfsdk:"query_filter"`
+	Fields      types.List          `tfsdk:"fields"`
+	SortKeys    types.List          `tfsdk:"sort_keys"`
+	Clients     []OAuth2ClientModel `tfsdk:"clients"`
+}
+
+// OAuth2ClientModel describes one OAuth 2.0 client of the data source.
+type OAuth2ClientModel struct {
+	ID                      types.String `tfsdk:"id"`
+	ClientType              types.String `tfsdk:"client_type"`
+	Status                  types.String `tfsdk:"status"`
+	ClientName              types.String `tfsdk:"client_name"`
+	RedirectURIs            types.List   `tfsdk:"redirect_uris"`
+	Scopes                  types.List   `tfsdk:"scopes"`
+	DefaultScopes           types.List   `tfsdk:"default_scopes"`
+	GrantTypes              types.List   `tfsdk:"grant_types"`
+	ResponseTypes           types.List   `tfsdk:"response_types"`
+	TokenEndpointAuthMethod types.String `tfsdk:"token_endpoint_auth_method"`
+}
+
+func (d *OAuth2ClientsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_clients"
+}
+
+func (d *OAuth2ClientsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	values := func(description string) schema.ListAttribute {
+		return schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the OAuth 2.0 clients of a realm that match a CREST query. AM only lists OAuth 2.0 clients in full, so the provider applies the filter, sort keys and fields itself.",
+
+		Attributes: withQueryAttributes(map[string]schema.Attribute{
+			"clients": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching clients.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The client ID.",
+						},
+						"client_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "`Confidential` or `Public`.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "`Active` or `Inactive`.",
+						},
+						"client_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name shown to users.",
+						},
+						"redirect_uris":  values("The registered redirection URIs."),
+						"scopes":         values("The scopes the client may request."),
+						"default_scopes": values("The scopes granted when the client requests none."),
+						"grant_types":    values("The grant types the client may use, for example `implicit`."),
+						"response_types": values("The response types the client may use."),
+						"token_endpoint_auth_method": schema.StringAttribute{
+							Computed:    true,
+							Description: "How the client authenticates to the token endpoint, for example `client_secret_basic`.",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func (d *OAuth2ClientsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OAuth2ClientsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OAuth2ClientsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := queryParams(ctx, d.client, &data.Realm, data.QueryFilter, data.Fields, data.SortKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clients, err := d.client.AM.QueryOAuth2Clients(ctx, data.Realm.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to query OAuth2 clients, got error: %s", err))
+		return
+	}
+
+	data.Clients = make([]OAuth2ClientModel, 0, len(clients))
+	for _, c := range clients {
+		model := OAuth2ClientModel{
+			ID:                      types.StringValue(c.ID),
+			ClientType:              types.StringValue(c.ClientType),
+			Status:                  types.StringValue(c.Status),
+			ClientName:              types.StringValue(c.ClientName),
+			TokenEndpointAuthMethod: types.StringValue(c.TokenEndpointAuthMethod),
+		}
+		for target, values := range map[*types.List][]string{
+			&model.RedirectURIs:  c.RedirectURIs,
+			&model.Scopes:        c.Scopes,
+			&model.DefaultScopes: c.DefaultScopes,
+			&model.GrantTypes:    c.GrantTypes,
+			&model.ResponseTypes: c.ResponseTypes,
+		} {
+			var diags diag.Diagnostics
+			*target, diags = stringListValue(ctx, values)
+			resp.Diagnostics.Append(diags...)
+		}
+		data.Clients = append(data.Clients, model)
+	}
+
+	tflog.Trace(ctx, "read an OAuth2 clients data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
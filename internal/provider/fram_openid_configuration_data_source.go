@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OpenIDConfigurationDataSource{}
+
+func NewOpenIDConfigurationDataSource() datasource.DataSource {
+	return &OpenIDConfigurationDataSource{}
+}
+
+// OpenIDConfigurationDataSource defines the data source implementation.
+type OpenIDConfigurationDataSource struct {
+	client *FRAMClient
+}
+
+// OpenIDConfigurationDataSourceModel describes the data source data model.
+type OpenIDConfigurationDataSourceModel struct {
+	Realm                             types.String `tfsdk:"realm"`
+	Issuer                            types.String `tfsdk:"issuer"`
+	AuthorizationEndpoint             types.String `tfsdk:"authorization_endpoint"`
+	TokenEndpoint                     types.String `tfsdk:"token_endpoint"`
+	UserinfoEndpoint                  types.String `tfsdk:"userinfo_endpoint"`
+	JWKSURI                           types.String `tfsdk:"jwks_uri"`
+	EndSessionEndpoint                types.String `tfsdk:"end_session_endpoint"`
+	IntrospectionEndpoint             types.String `tfsdk:"introspection_endpoint"`
+	RevocationEndpoint                types.String `tfsdk:"revocation_endpoint"`
+	RegistrationEndpoint              types.String `tfsdk:"registration_endpoint"`
+	DeviceAuthorizationEndpoint       types.String `tfsdk:"device_authorization_endpoint"`
+	ScopesSupported                   types.List   `tfsdk:"scopes_supported"`
+	ResponseTypesSupported            types.List   `tfsdk:"response_types_supported"`
+	GrantTypesSupported               types.List   `tfsdk:"grant_types_supported"`
+	SubjectTypesSupported             types.List   `tfsdk:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  types.List   `tfsdk:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported types.List   `tfsdk:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                   types.List   `tfsdk:"claims_supported"`
+	CodeChallengeMethodsSupported     types.List   `tfsdk:"code_challenge_methods_supported"`
+	JSON                              types.String `tfsdk:"json"`
+}
+
+func (d *OpenIDConfigurationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_openid_configuration"
+}
+
+func (d *OpenIDConfigurationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	endpoint := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:    true,
+			Description: description,
+		}
+	}
+	values := func(description string) schema.ListAttribute {
+		return schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the OpenID Connect discovery document (`.well-known/openid-configuration`) of a realm, for example to configure API gateways or identity federation against AM.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to read, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"issuer":                                endpoint("The issuer identifier of the realm."),
+			"authorization_endpoint":                endpoint("The URL of the authorization endpoint."),
+			"token_endpoint":                        endpoint("The URL of the token endpoint."),
+			"userinfo_endpoint":                     endpoint("The URL of the userinfo endpoint."),
+			"jwks_uri":                              endpoint("The URL of the JSON Web Key Set, see the `fram_jwks` data source."),
+			"end_session_endpoint":                  endpoint("The URL of the end session endpoint."),
+			"introspection_endpoint":                endpoint("The URL of the token introspection endpoint."),
+			"revocation_endpoint":                   endpoint("The URL of the token revocation endpoint."),
+			"registration_endpoint":                 endpoint("The URL of the dynamic client registration endpoint."),
+			"device_authorization_endpoint":         endpoint("The URL of the device authorization endpoint."),
+			"scopes_supported":                      values("The scopes the realm supports."),
+			"response_types_supported":              values("The response types the realm supports."),
+			"grant_types_supported":                 values("The grant types the realm supports."),
+			"subject_types_supported":               values("The subject identifier types the realm supports."),
+			"id_token_signing_alg_values_supported": values("The algorithms the realm can sign ID tokens with."),
+			"token_endpoint_auth_methods_supported": values("The client authentication methods the token endpoint accepts."),
+			"claims_supported":                      values("The claims the realm can supply."),
+			"code_challenge_methods_supported":      values("The PKCE code challenge methods the realm supports."),
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Description: "The complete discovery document as JSON, for members not exposed as attributes.",
+			},
+		},
+	}
+}
+
+func (d *OpenIDConfigurationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OpenIDConfigurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OpenIDConfigurationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := d.client.AM.GetOpenIDConfiguration(ctx, realm)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read OpenID configuration, got error: %s", err))
+		return
+	}
+
+	data.Issuer = types.StringValue(config.Issuer)
+	data.AuthorizationEndpoint = types.StringValue(config.AuthorizationEndpoint)
+	data.TokenEndpoint = types.StringValue(config.TokenEndpoint)
+	data.UserinfoEndpoint = types.StringValue(config.UserinfoEndpoint)
+	data.JWKSURI = types.StringValue(config.JWKSURI)
+	data.EndSessionEndpoint = types.StringValue(config.EndSessionEndpoint)
+	data.IntrospectionEndpoint = types.StringValue(config.IntrospectionEndpoint)
+	data.RevocationEndpoint = types.StringValue(config.RevocationEndpoint)
+	data.RegistrationEndpoint = types.StringValue(config.RegistrationEndpoint)
+	data.DeviceAuthorizationEndpoint = types.StringValue(config.DeviceAuthorizationEndpoint)
+	data.JSON = types.StringValue(string(config.Raw))
+
+	lists := map[*types.List][]string{
+		&data.ScopesSupported:                   config.ScopesSupported,
+		&data.ResponseTypesSupported:            config.ResponseTypesSupported,
+		&data.GrantTypesSupported:               config.GrantTypesSupported,
+		&data.SubjectTypesSupported:             config.SubjectTypesSupported,
+		&data.IDTokenSigningAlgValuesSupported:  config.IDTokenSigningAlgValuesSupported,
+		&data.TokenEndpointAuthMethodsSupported: config.TokenEndpointAuthMethodsSupported,
+		&data.ClaimsSupported:                   config.ClaimsSupported,
+		&data.CodeChallengeMethodsSupported:     config.CodeChallengeMethodsSupported,
+	}
+	for target, values := range lists {
+		var diags diag.Diagnostics
+		*target, diags = stringListValue(ctx, values)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	tflog.Trace(ctx, "read an OpenID configuration data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
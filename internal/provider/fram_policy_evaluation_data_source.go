@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PolicyEvaluationDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &PolicyEvaluationDataSource{}
+
+func NewPolicyEvaluationDataSource() datasource.DataSource {
+	return &PolicyEvaluationDataSource{}
+}
+
+// PolicyEvaluationDataSource defines the data source implementation.
+type PolicyEvaluationDataSource struct {
+	client *FRAMClient
+}
+
+// PolicyEvaluationDataSourceModel describes the data source data model.
+type PolicyEvaluationDataSourceModel struct {
+	Realm       types.String          `tfsdk:"realm"`
+	Application types.String          `tfsdk:"application"`
+	Resources   types.List            `tfsdk:"resources"`
+	Subject     *PolicySubjectModel   `tfsdk:"subject"`
+	Environment types.Map             `tfsdk:"environment"`
+	Decisions   []PolicyDecisionModel `tfsdk:"decisions"`
+}
+
+// PolicySubjectModel describes whom the policies are evaluated for.
+type PolicySubjectModel struct {
+	SSOToken types.String `tfsdk:"sso_token"`
+	JWT      types.String `tfsdk:"jwt"`
+	Claims   types.Map    `tfsdk:"claims"`
+}
+
+// PolicyDecisionModel describes the decision for one resource.
+type PolicyDecisionModel struct {
+	Resource   types.String `tfsdk:"resource"`
+	Actions    types.Map    `tfsdk:"actions"`
+	Advices    types.Map    `tfsdk:"advices"`
+	Attributes types.Map    `tfsdk:"attributes"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+}
+
+var stringListType = types.ListType{ElemType: types.StringType}
+
+func (d *PolicyEvaluationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_evaluation"
+}
+
+func (d *PolicyEvaluationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Evaluates the policies of a policy set for a subject and a list of resources, as a policy enforcement point would. " +
+			"Use it in `check` blocks or `terraform test` to assert authorization decisions.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm of the policy set, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"application": schema.StringAttribute{
+				Required:    true,
+				Description: "The policy set to evaluate, for example `iPlanetAMWebAgentService`.",
+			},
+			"resources": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The resources to evaluate, for example `[\"https://app.example.com/api/orders\"]`.",
+			},
+			"subject": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The subject to evaluate the policies for. Set exactly one of its attributes.",
+				Attributes: map[string]schema.Attribute{
+					"sso_token": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The SSO token of the subject.",
+					},
+					"jwt": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "An OpenID Connect ID token of the subject.",
+					},
+					"claims": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "JWT claims describing the subject, for example `{ sub = \"demo\" }`.",
+					},
+				},
+			},
+			"environment": schema.MapAttribute{
+				ElementType: stringListType,
+				Optional:    true,
+				Description: "Environment values used by policy conditions, for example `{ IP = [\"10.0.0.1\"] }`.",
+			},
+			"decisions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The decision for each resource.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource": schema.StringAttribute{
+							Computed:    true,
+							Description: "The evaluated resource.",
+						},
+						"actions": schema.MapAttribute{
+							ElementType: types.BoolType,
+							Computed:    true,
+							Description: "Whether each action is allowed, for example `{ GET = true, POST = false }`. Actions no policy decides on are absent.",
+						},
+						"advices": schema.MapAttribute{
+							ElementType: stringListType,
+							Computed:    true,
+							Description: "The advices returned with the decision, for example an authentication level the subject must reach.",
+						},
+						"attributes": schema.MapAttribute{
+							ElementType: stringListType,
+							Computed:    true,
+							Description: "The response attributes returned with the decision.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The time in milliseconds since the epoch until which the decision may be cached.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PolicyEvaluationDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data PolicyEvaluationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Subject == nil {
+		return
+	}
+
+	set := 0
+	for _, v := range []interface{ IsNull() bool }{data.Subject.SSOToken, data.Subject.JWT, data.Subject.Claims} {
+		if !v.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("subject"),
+			"Invalid Subject",
+			"Exactly one of sso_token, jwt and claims must be set.",
+		)
+	}
+}
+
+func (d *PolicyEvaluationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PolicyEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PolicyEvaluationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	evaluation, diags := data.toAPI(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	decisions, err := d.client.AM.EvaluatePolicies(ctx, realm, evaluation)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to evaluate policies, got error: %s", err))
+		return
+	}
+
+	data.Decisions = make([]PolicyDecisionModel, 0, len(decisions))
+	for _, decision := range decisions {
+		model, diags := policyDecisionValue(ctx, decision)
+		resp.Diagnostics.Append(diags...)
+		data.Decisions = append(data.Decisions, model)
+	}
+
+	tflog.Trace(ctx, "read a policy evaluation data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *PolicyEvaluationDataSourceModel) toAPI(ctx context.Context) (am.PolicyEvaluationRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	evaluation := am.PolicyEvaluationRequest{
+		Application: m.Application.ValueString(),
+		Subject: am.PolicySubject{
+			SSOToken: m.Subject.SSOToken.ValueString(),
+			JWT:      m.Subject.JWT.ValueString(),
+		},
+	}
+
+	diags.Append(m.Resources.ElementsAs(ctx, &evaluation.Resources, false)...)
+	if !m.Environment.IsNull() {
+		diags.Append(m.Environment.ElementsAs(ctx, &evaluation.Environment, false)...)
+	}
+	if !m.Subject.Claims.IsNull() {
+		var claims map[string]string
+		diags.Append(m.Subject.Claims.ElementsAs(ctx, &claims, false)...)
+		evaluation.Subject.Claims = make(map[string]interface{}, len(claims))
+		for k, v := range claims {
+			evaluation.Subject.Claims[k] = v
+		}
+	}
+
+	return evaluation, diags
+}
+
+func policyDecisionValue(ctx context.Context, decision am.PolicyDecision) (PolicyDecisionModel, diag.Diagnostics) {
+	var diags, d diag.Diagnostics
+	model := PolicyDecisionModel{
+		Resource: types.StringValue(decision.Resource),
+		TTL:      types.Int64Value(decision.TTL),
+	}
+
+	actions := decision.Actions
+	if actions == nil {
+		actions = map[string]bool{}
+	}
+	model.Actions, d = types.MapValueFrom(ctx, types.BoolType, actions)
+	diags.Append(d...)
+
+	advices := decision.Advices
+	if advices == nil {
+		advices = map[string][]string{}
+	}
+	model.Advices, d = types.MapValueFrom(ctx, stringListType, advices)
+	diags.Append(d...)
+
+	attributes := decision.Attributes
+	if attributes == nil {
+		attributes = map[string][]string{}
+	}
+	model.Attributes, d = types.MapValueFrom(ctx, stringListType, attributes)
+	diags.Append(d...)
+
+	return model, diags
+}
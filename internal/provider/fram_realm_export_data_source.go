@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RealmExportDataSource{}
+
+func NewRealmExportDataSource() datasource.DataSource {
+	return &RealmExportDataSource{}
+}
+
+// RealmExportDataSource defines the data source implementation.
+type RealmExportDataSource struct {
+	client *FRAMClient
+}
+
+// RealmExportDataSourceModel describes the data source data model.
+type RealmExportDataSourceModel struct {
+	Realm         types.String `tfsdk:"realm"`
+	Sections      types.Set    `tfsdk:"sections"`
+	JSON          types.String `tfsdk:"json"`
+	SHA256        types.String `tfsdk:"sha256"`
+	SectionHashes types.Map    `tfsdk:"section_hashes"`
+}
+
+func (d *RealmExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_realm_export"
+}
+
+func (d *RealmExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports the configuration of a realm as a single canonical JSON document with a stable content hash, for backups, environment comparisons and change detection in CI. " +
+			"Object keys are sorted, objects are keyed by ID, and fields that change without a configuration change (`" + strings.Join(am.VolatileFields, "`, `") + "`) are removed, " +
+			"so two realms with the same configuration produce the same hash.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to export, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"sections": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The sections to export. One or more of `" + strings.Join(am.ExportSections, "`, `") + "`. Defaults to every section.",
+				Validators: []validator.Set{
+					setOneOfValidator{values: am.ExportSections},
+				},
+			},
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Description: "The export as canonical JSON: an object of sections, each an object of configuration objects keyed by ID. Agents are keyed by `type/id`.",
+			},
+			"sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "The hex SHA-256 of `json`.",
+			},
+			"section_hashes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The hex SHA-256 of each exported section, to narrow down which part of the realm changed.",
+			},
+		},
+	}
+}
+
+func (d *RealmExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RealmExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RealmExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sections := am.ExportSections
+	if !data.Sections.IsNull() {
+		wanted, diags := stringSetElementsAs(ctx, data.Sections)
+		resp.Diagnostics.Append(diags...)
+		sections = slices.DeleteFunc(slices.Clone(am.ExportSections), func(s string) bool { return !slices.Contains(wanted, s) })
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export, err := d.client.AM.ExportRealm(ctx, realm, sections)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export realm %q, got error: %s", realm, err))
+		return
+	}
+
+	document, err := am.CanonicalJSON(export)
+	hashes := make(map[string]string, len(export))
+	for section, objects := range export {
+		if err == nil {
+			hashes[section], err = am.ContentHash(objects)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode the export of realm %q, got error: %s", realm, err))
+		return
+	}
+	sum := sha256.Sum256(document)
+
+	data.JSON = types.StringValue(string(document))
+	data.SHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+	data.SectionHashes, diags = types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read a realm export data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
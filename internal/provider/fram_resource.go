@@ -18,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BaseURLSourceResource{}
 var _ resource.ResourceWithImportState = &BaseURLSourceResource{}
+var _ resource.ResourceWithModifyPlan = &BaseURLSourceResource{}
 
 func NewBaseURLSourceResource() resource.Resource {
 	return &BaseURLSourceResource{}
@@ -25,7 +26,7 @@ func NewBaseURLSourceResource() resource.Resource {
 
 // BaseURLSourceResource defines the resource implementation.
 type BaseURLSourceResource struct {
-	client *fram.Client
+	client *FRAMClient
 }
 
 // BaseURLSourceModel describes the resource data model.
@@ -37,7 +38,7 @@ type BaseURLSourceModel struct {
 }
 
 func (r *BaseURLSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = "fram_" + req.ProviderTypeName
+	resp.TypeName = req.ProviderTypeName + "_baseurlsource"
 }
 
 func (r *BaseURLSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -81,12 +82,12 @@ func (r *BaseURLSourceResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*fram.Client)
+	client, ok := req.ProviderData.(*FRAMClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -208,6 +209,19 @@ func (r *BaseURLSourceResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 }
 
+func (r *BaseURLSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkBaseURLSourceAuth(r.client, "fram_baseurlsource", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkVersion(ctx, r.client, req, versionRequirement{
+		TypeName: "fram_baseurlsource",
+		Minimum:  am65,
+		// Identity Cloud does not load custom classes.
+		SelfManagedAttributes: []string{"extension_class_name"},
+	}, &resp.Diagnostics)
+}
+
 func (r *BaseURLSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
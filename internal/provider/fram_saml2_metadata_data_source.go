@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SAML2MetadataDataSource{}
+
+func NewSAML2MetadataDataSource() datasource.DataSource {
+	return &SAML2MetadataDataSource{}
+}
+
+// SAML2MetadataDataSource defines the data source implementation.
+type SAML2MetadataDataSource struct {
+	client *FRAMClient
+}
+
+// SAML2MetadataDataSourceModel describes the data source data model.
+type SAML2MetadataDataSourceModel struct {
+	Realm                     types.String            `tfsdk:"realm"`
+	EntityID                  types.String            `tfsdk:"entity_id"`
+	XML                       types.String            `tfsdk:"xml"`
+	Certificates              []SAML2CertificateModel `tfsdk:"certificates"`
+	SingleSignOnServices      []SAML2EndpointModel    `tfsdk:"single_sign_on_services"`
+	SingleLogoutServices      []SAML2EndpointModel    `tfsdk:"single_logout_services"`
+	AssertionConsumerServices []SAML2EndpointModel    `tfsdk:"assertion_consumer_services"`
+}
+
+// SAML2CertificateModel describes a certificate published in the metadata.
+type SAML2CertificateModel struct {
+	Role           types.String `tfsdk:"role"`
+	Use            types.String `tfsdk:"use"`
+	CertificatePEM types.String `tfsdk:"certificate_pem"`
+	Subject        types.String `tfsdk:"subject"`
+	Expiry         types.String `tfsdk:"expiry"`
+}
+
+// SAML2EndpointModel describes an endpoint published in the metadata.
+type SAML2EndpointModel struct {
+	Role             types.String `tfsdk:"role"`
+	Binding          types.String `tfsdk:"binding"`
+	Location         types.String `tfsdk:"location"`
+	ResponseLocation types.String `tfsdk:"response_location"`
+}
+
+func (d *SAML2MetadataDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saml2_metadata"
+}
+
+func (d *SAML2MetadataDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	endpoints := func(description string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			Computed:    true,
+			Description: description,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"role": schema.StringAttribute{
+						Computed:    true,
+						Description: "The role publishing the endpoint, `idp` or `sp`.",
+					},
+					"binding": schema.StringAttribute{
+						Computed:    true,
+						Description: "The SAML binding, for example `urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST`.",
+					},
+					"location": schema.StringAttribute{
+						Computed:    true,
+						Description: "The URL of the endpoint.",
+					},
+					"response_location": schema.StringAttribute{
+						Computed:    true,
+						Description: "The URL responses are sent to, if it differs from `location`.",
+					},
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports the SAML 2.0 metadata of an entity, for example to hand a hosted identity provider to a partner or to configure it as a remote entity elsewhere.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm of the entity, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"entity_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The entity ID, for example `https://am.example.com/openam`.",
+			},
+			"xml": schema.StringAttribute{
+				Computed:    true,
+				Description: "The metadata XML exactly as exported by AM.",
+			},
+			"certificates": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The certificates of the entity.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Computed:    true,
+							Description: "The role publishing the certificate, `idp` or `sp`.",
+						},
+						"use": schema.StringAttribute{
+							Computed:    true,
+							Description: "`signing`, `encryption`, or empty when the certificate serves both.",
+						},
+						"certificate_pem": schema.StringAttribute{
+							Computed:    true,
+							Description: "The certificate in PEM format.",
+						},
+						"subject": schema.StringAttribute{
+							Computed:    true,
+							Description: "The subject of the certificate.",
+						},
+						"expiry": schema.StringAttribute{
+							Computed:    true,
+							Description: "The RFC 3339 time at which the certificate expires.",
+						},
+					},
+				},
+			},
+			"single_sign_on_services":     endpoints("The single sign-on endpoints of the identity provider role."),
+			"single_logout_services":      endpoints("The single logout endpoints of both roles."),
+			"assertion_consumer_services": endpoints("The assertion consumer endpoints of the service provider role."),
+		},
+	}
+}
+
+func (d *SAML2MetadataDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SAML2MetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SAML2MetadataDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, err := d.client.AM.GetSAML2Metadata(ctx, realm, data.EntityID.ValueString())
+	if am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Entity Not Found", fmt.Sprintf("No SAML entity %q exists in realm %q.", data.EntityID.ValueString(), realm))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export SAML metadata, got error: %s", err))
+		return
+	}
+
+	data.XML = types.StringValue(string(metadata.Raw))
+	data.Certificates = []SAML2CertificateModel{}
+	data.SingleSignOnServices = []SAML2EndpointModel{}
+	data.SingleLogoutServices = []SAML2EndpointModel{}
+	data.AssertionConsumerServices = []SAML2EndpointModel{}
+
+	for _, r := range []struct {
+		name       string
+		descriptor *am.SAML2RoleDescriptor
+	}{
+		{"idp", metadata.IDP},
+		{"sp", metadata.SP},
+	} {
+		if r.descriptor == nil {
+			continue
+		}
+		resp.Diagnostics.Append(data.addRole(r.name, r.descriptor)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a SAML2 metadata data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (m *SAML2MetadataDataSourceModel) addRole(role string, descriptor *am.SAML2RoleDescriptor) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, key := range descriptor.KeyDescriptors {
+		cert, err := key.ParseCertificate()
+		if err != nil {
+			diags.AddError("Invalid Certificate", fmt.Sprintf("Unable to decode a %s certificate of the %s role, got error: %s", key.Use, role, err))
+			return diags
+		}
+		m.Certificates = append(m.Certificates, SAML2CertificateModel{
+			Role:           types.StringValue(role),
+			Use:            types.StringValue(key.Use),
+			CertificatePEM: types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))),
+			Subject:        types.StringValue(cert.Subject.String()),
+			Expiry:         types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	m.SingleSignOnServices = append(m.SingleSignOnServices, saml2Endpoints(role, descriptor.SingleSignOnServices)...)
+	m.SingleLogoutServices = append(m.SingleLogoutServices, saml2Endpoints(role, descriptor.SingleLogoutServices)...)
+	m.AssertionConsumerServices = append(m.AssertionConsumerServices, saml2Endpoints(role, descriptor.AssertionConsumerServices)...)
+
+	return diags
+}
+
+func saml2Endpoints(role string, endpoints []am.SAML2Endpoint) []SAML2EndpointModel {
+	models := make([]SAML2EndpointModel, 0, len(endpoints))
+	for _, e := range endpoints {
+		models = append(models, SAML2EndpointModel{
+			Role:             types.StringValue(role),
+			Binding:          types.StringValue(e.Binding),
+			Location:         types.StringValue(e.Location),
+			ResponseLocation: stringValueOrNull(e.ResponseLocation),
+		})
+	}
+	return models
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ScriptsDataSource{}
+
+func NewScriptsDataSource() datasource.DataSource {
+	return &ScriptsDataSource{}
+}
+
+// ScriptsDataSource defines the data source implementation.
+type ScriptsDataSource struct {
+	client *FRAMClient
+}
+
+// ScriptsDataSourceModel describes the data source data model.
+type ScriptsDataSourceModel struct {
+	Realm       types.String  `tfsdk:"realm"`
+	QueryFilter types.String  `tfsdk:"query_filter"`
+	Fields      types.List    `tfsdk:"fields"`
+	SortKeys    types.List    `tfsdk:"sort_keys"`
+	Scripts     []ScriptModel `tfsdk:"scripts"`
+}
+
+// ScriptModel describes one script of the data source.
+type ScriptModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Language    types.String `tfsdk:"language"`
+	Context     types.String `tfsdk:"context"`
+	Default     types.Bool   `tfsdk:"default"`
+	Source      types.String `tfsdk:"source"`
+}
+
+func (d *ScriptsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scripts"
+}
+
+func (d *ScriptsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the scripts of a realm that match a CREST query, for example `language eq \"GROOVY\"`. Every page of results is read.",
+
+		Attributes: withQueryAttributes(map[string]schema.Attribute{
+			"scripts": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The matching scripts.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The script ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the script.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the script.",
+						},
+						"language": schema.StringAttribute{
+							Computed:    true,
+							Description: "`JAVASCRIPT` or `GROOVY`.",
+						},
+						"context": schema.StringAttribute{
+							Computed:    true,
+							Description: "The script type, for example `AUTHENTICATION_TREE_DECISION_NODE`.",
+						},
+						"default": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the script ships with AM.",
+						},
+						"source": schema.StringAttribute{
+							Computed:    true,
+							Description: "The decoded source of the script.",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func (d *ScriptsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ScriptsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ScriptsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := queryParams(ctx, d.client, &data.Realm, data.QueryFilter, data.Fields, data.SortKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scripts, err := d.client.AM.QueryScripts(ctx, data.Realm.ValueString(), params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to query scripts, got error: %s", err))
+		return
+	}
+
+	data.Scripts = make([]ScriptModel, 0, len(scripts))
+	for _, s := range scripts {
+		source, err := s.Source()
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Script", fmt.Sprintf("Unable to decode script %q, got error: %s", s.ID, err))
+			return
+		}
+		data.Scripts = append(data.Scripts, ScriptModel{
+			ID:          types.StringValue(s.ID),
+			Name:        types.StringValue(s.Name),
+			Description: types.StringValue(s.Description),
+			Language:    types.StringValue(s.Language),
+			Context:     types.StringValue(s.Context),
+			Default:     types.BoolValue(s.Default),
+			Source:      types.StringValue(source),
+		})
+	}
+
+	tflog.Trace(ctx, "read a scripts data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
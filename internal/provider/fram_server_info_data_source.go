@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerInfoDataSource{}
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &ServerInfoDataSource{}
+}
+
+// ServerInfoDataSource defines the data source implementation.
+type ServerInfoDataSource struct {
+	client *FRAMClient
+}
+
+// ServerInfoDataSourceModel describes the data source data model.
+type ServerInfoDataSourceModel struct {
+	Realm                                 types.String          `tfsdk:"realm"`
+	Version                               types.String          `tfsdk:"version"`
+	FullVersion                           types.String          `tfsdk:"full_version"`
+	Revision                              types.String          `tfsdk:"revision"`
+	BuildDate                             types.String          `tfsdk:"build_date"`
+	CookieName                            types.String          `tfsdk:"cookie_name"`
+	SecureCookie                          types.Bool            `tfsdk:"secure_cookie"`
+	Domains                               types.List            `tfsdk:"domains"`
+	Lang                                  types.String          `tfsdk:"lang"`
+	ProtectedUserAttributes               types.List            `tfsdk:"protected_user_attributes"`
+	UserIDAttributes                      types.List            `tfsdk:"user_id_attributes"`
+	ForgotPassword                        types.Bool            `tfsdk:"forgot_password"`
+	ForgotUsername                        types.Bool            `tfsdk:"forgot_username"`
+	KBAEnabled                            types.Bool            `tfsdk:"kba_enabled"`
+	SelfRegistration                      types.Bool            `tfsdk:"self_registration"`
+	SuccessfulUserRegistrationDestination types.String          `tfsdk:"successful_user_registration_destination"`
+	ZeroPageLogin                         types.Bool            `tfsdk:"zero_page_login"`
+	XUIUserSessionValidation              types.Bool            `tfsdk:"xui_user_session_validation"`
+	FileBasedConfiguration                types.Bool            `tfsdk:"file_based_configuration"`
+	SocialProviders                       []SocialProviderModel `tfsdk:"social_providers"`
+}
+
+// SocialProviderModel describes a social identity provider offered on the
+// login page of the realm.
+type SocialProviderModel struct {
+	DisplayName types.String `tfsdk:"display_name"`
+	AuthnChain  types.String `tfsdk:"authn_chain"`
+	IconPath    types.String `tfsdk:"icon_path"`
+}
+
+func (d *ServerInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+func (d *ServerInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the version of the AM server and the public server information of a realm, such as the SSO cookie name and the social identity providers shown on the login page.",
+
+		Attributes: map[string]schema.Attribute{
+			"realm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The realm to read, for example `/alpha`. Defaults to the provider realm.",
+				Validators:  []validator.String{realmValidator{}},
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The AM release, for example `7.2.0`.",
+			},
+			"full_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The full version string including the build.",
+			},
+			"revision": schema.StringAttribute{
+				Computed:    true,
+				Description: "The source revision the server was built from.",
+			},
+			"build_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "The build date of the server.",
+			},
+			"cookie_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the SSO cookie, for example `iPlanetDirectoryPro`.",
+			},
+			"secure_cookie": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the SSO cookie is only sent over HTTPS.",
+			},
+			"domains": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The cookie domains.",
+			},
+			"lang": schema.StringAttribute{
+				Computed:    true,
+				Description: "The default locale of the user interface.",
+			},
+			"protected_user_attributes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The user attributes that can only be changed after re-entering the password.",
+			},
+			"user_id_attributes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The attributes users can sign in with besides their username.",
+			},
+			"forgot_password": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether legacy forgotten password self-service is enabled.",
+			},
+			"forgot_username": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether legacy forgotten username self-service is enabled.",
+			},
+			"kba_enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether knowledge-based authentication questions are enabled.",
+			},
+			"self_registration": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether legacy user self-registration is enabled.",
+			},
+			"successful_user_registration_destination": schema.StringAttribute{
+				Computed:    true,
+				Description: "Where users are sent after self-registration.",
+			},
+			"zero_page_login": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether credentials may be supplied as request headers or parameters.",
+			},
+			"xui_user_session_validation": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the user interface validates the session of the user.",
+			},
+			"file_based_configuration": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the server reads its configuration from files rather than the configuration store.",
+			},
+			"social_providers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The social identity providers shown on the login page.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name shown to users.",
+						},
+						"authn_chain": schema.StringAttribute{
+							Computed:    true,
+							Description: "The authentication chain or journey the provider starts.",
+						},
+						"icon_path": schema.StringAttribute{
+							Computed:    true,
+							Description: "The path of the provider icon.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServerInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ServerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServerInfoDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, diags := resolveRealm(d.client, &data.Realm)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := d.client.AM.GetServerInfo(ctx, realm)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server information, got error: %s", err))
+		return
+	}
+
+	version, err := d.client.AM.GetServerVersion(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server version, got error: %s", err))
+		return
+	}
+
+	data.Version = types.StringValue(version.Version)
+	data.FullVersion = types.StringValue(version.FullVersion)
+	data.Revision = types.StringValue(version.Revision)
+	data.BuildDate = types.StringValue(version.Date)
+	data.CookieName = types.StringValue(info.CookieName)
+	data.SecureCookie = types.BoolValue(info.SecureCookie)
+	data.Lang = types.StringValue(info.Lang)
+	data.ForgotPassword = types.BoolValue(bool(info.ForgotPassword))
+	data.ForgotUsername = types.BoolValue(bool(info.ForgotUsername))
+	data.KBAEnabled = types.BoolValue(bool(info.KBAEnabled))
+	data.SelfRegistration = types.BoolValue(bool(info.SelfRegistration))
+	data.SuccessfulUserRegistrationDestination = types.StringValue(info.SuccessfulUserRegistrationDestination)
+	data.ZeroPageLogin = types.BoolValue(info.ZeroPageLogin.Enabled)
+	data.XUIUserSessionValidation = types.BoolValue(info.XUIUserSessionValidationEnabled)
+	data.FileBasedConfiguration = types.BoolValue(info.FileBasedConfiguration)
+
+	data.Domains, diags = stringListValue(ctx, info.Domains)
+	resp.Diagnostics.Append(diags...)
+	data.ProtectedUserAttributes, diags = stringListValue(ctx, info.ProtectedUserAttributes)
+	resp.Diagnostics.Append(diags...)
+	data.UserIDAttributes, diags = stringListValue(ctx, info.UserIDAttributes)
+	resp.Diagnostics.Append(diags...)
+
+	data.SocialProviders = make([]SocialProviderModel, 0, len(info.SocialImplementations))
+	for _, social := range info.SocialImplementations {
+		data.SocialProviders = append(data.SocialProviders, SocialProviderModel{
+			DisplayName: types.StringValue(social.DisplayName),
+			AuthnChain:  types.StringValue(social.AuthnChain),
+			IconPath:    types.StringValue(social.IconPath),
+		})
+	}
+
+	tflog.Trace(ctx, "read a server info data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ServerResource{}
+var _ resource.ResourceWithImportState = &ServerResource{}
+var _ resource.ResourceWithModifyPlan = &ServerResource{}
+
+func NewServerResource() resource.Resource {
+	return &ServerResource{}
+}
+
+// ServerResource defines the resource implementation.
+type ServerResource struct {
+	client *FRAMClient
+}
+
+// ServerModel describes the resource data model.
+type ServerModel struct {
+	ID   types.String `tfsdk:"id"`
+	URL  types.String `tfsdk:"url"`
+	Site types.String `tfsdk:"site"`
+	serverPropertiesModel
+}
+
+func (r *ServerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+func (r *ServerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := serverPropertyAttributes(true)
+	attributes["id"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The server ID assigned by AM, for example `01`.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["url"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The URL of the server including its deployment context, for example `https://am1.example.com:8443/openam`. Changing it registers a new server.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["site"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "The name of the site the server belongs to, for example `fram_site.example.name`.",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a server of a self-managed AM deployment, its site membership and the properties it overrides from the server defaults. " +
+			serverPropertiesDescription,
+		Attributes: attributes,
+	}
+}
+
+func (r *ServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServerModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.CreateServer(ctx, am.Server{
+		URL:      data.URL.ValueString(),
+		SiteName: data.Site.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create server, got error: %s", err))
+		return
+	}
+	data.ID = types.StringValue(result.ID)
+
+	// Save the server before its properties so a failure below does not
+	// leave an unmanaged server behind.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(writeServerProperties(ctx, r.client.AM, result.ID, &data.serverPropertiesModel, nil, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a server resource")
+}
+
+func (r *ServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ServerModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetServer(ctx, data.ID.ValueString())
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read server, got error: %s", err))
+		return
+	}
+
+	data.URL = types.StringValue(result.URL)
+	data.Site = stringValueOrNull(result.SiteName)
+	resp.Diagnostics.Append(readServerProperties(ctx, r.client.AM, result.ID, &data.serverPropertiesModel, true)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ServerModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Site.Equal(state.Site) {
+		_, err := r.client.AM.UpdateServer(ctx, am.Server{
+			ID:       data.ID.ValueString(),
+			URL:      data.URL.ValueString(),
+			SiteName: data.Site.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update server, got error: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(writeServerProperties(ctx, r.client.AM, data.ID.ValueString(), &data.serverPropertiesModel, &state.serverPropertiesModel, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ServerModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AM.DeleteServer(ctx, data.ID.ValueString())
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete server, got error: %s", err))
+	}
+}
+
+func (r *ServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_server", Minimum: am65, SelfManaged: true}, &resp.Diagnostics)
+}
+
+func (r *ServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
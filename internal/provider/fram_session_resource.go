@@ -0,0 +1,274 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SessionResource{}
+var _ resource.ResourceWithImportState = &SessionResource{}
+var _ resource.ResourceWithModifyPlan = &SessionResource{}
+
+func NewSessionResource() resource.Resource {
+	return &SessionResource{}
+}
+
+// SessionResource defines the resource implementation.
+type SessionResource struct {
+	client *FRAMClient
+}
+
+// SessionModel describes the resource data model.
+type SessionModel struct {
+	ID                types.String `tfsdk:"id"`
+	MaxSessionTime    types.Int64  `tfsdk:"max_session_time"`
+	MaxIdleTime       types.Int64  `tfsdk:"max_idle_time"`
+	MaxCachingTime    types.Int64  `tfsdk:"max_caching_time"`
+	QuotaLimit        types.Int64  `tfsdk:"quota_limit"`
+	PropertyWhitelist types.Set    `tfsdk:"property_whitelist"`
+}
+
+func (r *SessionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+func (r *SessionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the Session service and the Session Property Whitelist service of the provider realm. " +
+			"Destroying the resource removes both services from the realm so the global defaults apply again.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The realm the services are configured in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_session_time": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(120),
+				Description: "Maximum time in minutes a session can remain valid before the user must authenticate again.",
+			},
+			"max_idle_time": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(30),
+				Description: "Maximum time in minutes a session can remain idle before it expires.",
+			},
+			"max_caching_time": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3),
+				Description: "Maximum time in minutes before a client refreshes its cached session information.",
+			},
+			"quota_limit": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(5),
+				Description: "Maximum number of concurrent sessions a user can have when session quotas are enforced.",
+			},
+			"property_whitelist": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, nil)),
+				Description: "Session properties that users can read and set with the session REST endpoint.",
+			},
+		},
+	}
+}
+
+func (r *SessionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SessionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SessionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a session resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SessionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SessionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session, err := r.client.AM.GetSessionService(ctx)
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Session service, got error: %s", err))
+		return
+	}
+
+	whitelist, err := r.client.AM.GetSessionPropertyWhitelist(ctx)
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Session Property Whitelist service, got error: %s", err))
+		return
+	}
+	if whitelist == nil {
+		whitelist = &am.SessionPropertyWhitelist{}
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, r.client.AM.Realm, session, whitelist)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SessionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SessionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SessionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SessionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.AM.DeleteSessionPropertyWhitelist(ctx); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Session Property Whitelist service, got error: %s", err))
+		return
+	}
+	if err := r.client.AM.DeleteSessionService(ctx); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Session service, got error: %s", err))
+	}
+}
+
+func (r *SessionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_session", Minimum: am65}, &resp.Diagnostics)
+}
+
+func (r *SessionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// write pushes the planned values to both services and refreshes data from
+// the responses.
+func (r *SessionResource) write(ctx context.Context, data *SessionModel, diags *diag.Diagnostics) {
+	whitelist, d := stringSetElementsAs(ctx, data.PropertyWhitelist)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	session, err := r.client.AM.UpdateSessionService(ctx, am.SessionService{
+		Dynamic: am.SessionDynamic{
+			MaxSessionTime: data.MaxSessionTime.ValueInt64(),
+			MaxIdleTime:    data.MaxIdleTime.ValueInt64(),
+			MaxCachingTime: data.MaxCachingTime.ValueInt64(),
+			QuotaLimit:     data.QuotaLimit.ValueInt64(),
+		},
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update Session service, got error: %s", err))
+		return
+	}
+
+	// The query property list is not managed here, so carry it over.
+	current, err := r.client.AM.GetSessionPropertyWhitelist(ctx)
+	if err != nil && !am.IsNotFound(err) {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read Session Property Whitelist service, got error: %s", err))
+		return
+	}
+	if current == nil {
+		current = &am.SessionPropertyWhitelist{WhitelistedQueryProperties: []string{}}
+	}
+	current.SessionPropertyWhitelist = whitelist
+
+	result, err := r.client.AM.UpdateSessionPropertyWhitelist(ctx, *current)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update Session Property Whitelist service, got error: %s", err))
+		return
+	}
+
+	diags.Append(data.fromAPI(ctx, r.client.AM.Realm, session, result)...)
+}
+
+func (m *SessionModel) fromAPI(ctx context.Context, realm string, session *am.SessionService, whitelist *am.SessionPropertyWhitelist) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(realm)
+	m.MaxSessionTime = types.Int64Value(session.Dynamic.MaxSessionTime)
+	m.MaxIdleTime = types.Int64Value(session.Dynamic.MaxIdleTime)
+	m.MaxCachingTime = types.Int64Value(session.Dynamic.MaxCachingTime)
+	m.QuotaLimit = types.Int64Value(session.Dynamic.QuotaLimit)
+	m.PropertyWhitelist, diags = stringSetValue(ctx, whitelist.SessionPropertyWhitelist)
+
+	return diags
+}
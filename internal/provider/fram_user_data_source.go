@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource defines the data source implementation.
+type UserDataSource struct {
+	client *FRAMClient
+}
+
+// UserDataSourceModel describes the data source data model.
+type UserDataSourceModel struct {
+	QueryFilter types.String `tfsdk:"query_filter"`
+	ID          types.String `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	Status      types.String `tfsdk:"status"`
+	Email       types.String `tfsdk:"email"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single user in the identity store of the provider realm. The query must match exactly one user.",
+
+		Attributes: map[string]schema.Attribute{
+			"query_filter": schema.StringAttribute{
+				Required:    true,
+				Description: "A CREST `_queryFilter` expression, for example `userName eq \"demo\"` or `mail eq \"demo@example.com\"`.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the user.",
+			},
+			"username": schema.StringAttribute{
+				Computed:    true,
+				Description: "The username.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The account status, either `Active` or `Inactive`.",
+			},
+			"email": schema.StringAttribute{
+				Computed:    true,
+				Description: "The email address of the user.",
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.AM.QueryUsers(ctx, am.QueryParams{
+		Filter: data.QueryFilter.ValueString(),
+		Fields: []string{"_id", "username", "mail", "inetUserStatus"},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to query users, got error: %s", err))
+		return
+	}
+	if len(users) != 1 {
+		resp.Diagnostics.AddError("Unexpected Query Result", fmt.Sprintf("Expected the query %q to match exactly one user, got %d.", data.QueryFilter.ValueString(), len(users)))
+		return
+	}
+
+	user := users[0]
+	data.ID = types.StringValue(user.ID)
+	data.Username = types.StringValue(user.Username)
+	data.Status = types.StringValue(user.Status)
+	data.Email = types.StringValue(user.Mail)
+
+	tflog.Trace(ctx, "read a user data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserResource{}
+var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+// UserResource defines the resource implementation.
+type UserResource struct {
+	client *FRAMClient
+}
+
+// UserModel describes the resource data model.
+type UserModel struct {
+	ID         types.String `tfsdk:"id"`
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	Status     types.String `tfsdk:"status"`
+	Email      types.String `tfsdk:"email"`
+	Attributes types.Map    `tfsdk:"attributes"`
+}
+
+func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a user in the identity store of the provider realm.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The identifier of the user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "The username. Changing it creates a new user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "The password. It is sent when the user is created and whenever it changes in the configuration. " +
+					"AM never returns it, so a password changed outside Terraform is not detected, and removing it leaves the " +
+					"current password in place.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Active"),
+				Description: "The account status, either `Active` or `Inactive`.",
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"Active", "Inactive"}},
+				},
+			},
+			"email": schema.StringAttribute{
+				Optional:    true,
+				Description: "The email address of the user.",
+			},
+			"attributes": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional single-valued identity store attributes, for example `givenName` and `sn`. Only the attributes listed here are checked for drift, and importing a user leaves them out.",
+			},
+		},
+	}
+}
+
+func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, diags := data.toAPI(ctx, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	user.Password = data.Password.ValueString()
+
+	result, err := r.client.AM.CreateUser(ctx, user)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	tflog.Trace(ctx, "created a user resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetUser(ctx, data.ID.ValueString())
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state UserModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, diags := data.toAPI(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The user keeps its password unless a new one is configured.
+	if !data.Password.IsNull() && !data.Password.Equal(state.Password) {
+		user.Password = data.Password.ValueString()
+	}
+
+	result, err := r.client.AM.UpdateUser(ctx, data.ID.ValueString(), user)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AM.DeleteUser(ctx, data.ID.ValueString())
+	if err != nil && !am.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+	}
+}
+
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_user", Minimum: am65}, &resp.Diagnostics)
+}
+
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// A user has many attributes the identity store maintains itself, so
+	// they cannot all be tracked; say so rather than dropping them silently.
+	resp.Diagnostics.AddAttributeWarning(path.Root("attributes"), "Attributes Not Imported",
+		"The custom attributes of the user are not imported. Add the ones to manage to attributes in the configuration.")
+}
+
+// toAPI builds the request body. Attributes that were in the prior state but
+// are no longer configured are sent empty so that AM removes them.
+func (m *UserModel) toAPI(ctx context.Context, prior *UserModel) (am.User, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	user := am.User{
+		Username:   m.Username.ValueString(),
+		Mail:       m.Email.ValueString(),
+		Status:     m.Status.ValueString(),
+		Attributes: map[string][]string{},
+	}
+
+	if prior != nil && !prior.Attributes.IsNull() {
+		var old map[string]string
+		diags.Append(prior.Attributes.ElementsAs(ctx, &old, false)...)
+		for k := range old {
+			user.Attributes[k] = []string{}
+		}
+	}
+	if !m.Attributes.IsNull() {
+		var attrs map[string]string
+		diags.Append(m.Attributes.ElementsAs(ctx, &attrs, false)...)
+		for k, v := range attrs {
+			user.Attributes[k] = []string{v}
+		}
+	}
+
+	return user, diags
+}
+
+// fromAPI refreshes the model from AM. The password is never read back and
+// only the custom attributes already tracked in the model are refreshed.
+func (m *UserModel) fromAPI(ctx context.Context, user *am.User) diag.Diagnostics {
+	m.ID = types.StringValue(user.ID)
+	m.Username = types.StringValue(user.Username)
+	m.Status = types.StringValue(user.Status)
+	m.Email = stringValueOrNull(user.Mail)
+
+	if m.Attributes.IsNull() || m.Attributes.IsUnknown() {
+		m.Attributes = types.MapNull(types.StringType)
+		return nil
+	}
+
+	attrs := map[string]attr.Value{}
+	for k := range m.Attributes.Elements() {
+		if values, ok := user.Attributes[k]; ok && len(values) > 0 {
+			attrs[k] = types.StringValue(values[0])
+		}
+	}
+
+	var diags diag.Diagnostics
+	m.Attributes, diags = types.MapValue(types.StringType, attrs)
+	return diags
+}
@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ValidationResource{}
+var _ resource.ResourceWithImportState = &ValidationResource{}
+var _ resource.ResourceWithModifyPlan = &ValidationResource{}
+
+func NewValidationResource() resource.Resource {
+	return &ValidationResource{}
+}
+
+// ValidationResource defines the resource implementation.
+type ValidationResource struct {
+	client *FRAMClient
+}
+
+// ValidationModel describes the resource data model.
+type ValidationModel struct {
+	ID                    types.String `tfsdk:"id"`
+	ValidGotoDestinations types.Set    `tfsdk:"valid_goto_destinations"`
+}
+
+func (r *ValidationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_validation"
+}
+
+func (r *ValidationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the Validation service of the provider realm, which lists the URLs AM may redirect to after login and logout.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The realm the service is configured in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"valid_goto_destinations": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "URL patterns allowed as `goto` and success URLs, for example `https://app.example.com:443/*?*`.",
+				Validators: []validator.Set{
+					urlPatternSetValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *ValidationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ValidationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ValidationModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a validation resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ValidationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ValidationModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.GetValidationService(ctx)
+	if am.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Validation service, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromAPI(ctx, r.client.AM.Realm, result)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ValidationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ValidationModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ValidationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ValidationModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.AM.DeleteValidationService(ctx); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Validation service, got error: %s", err))
+	}
+}
+
+func (r *ValidationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkVersion(ctx, r.client, req, versionRequirement{TypeName: "fram_validation", Minimum: am65}, &resp.Diagnostics)
+}
+
+func (r *ValidationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *ValidationResource) write(ctx context.Context, data *ValidationModel, diags *diag.Diagnostics) {
+	destinations, d := stringSetElementsAs(ctx, data.ValidGotoDestinations)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	result, err := r.client.AM.UpdateValidationService(ctx, am.ValidationService{ValidGotoDestinations: destinations})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update Validation service, got error: %s", err))
+		return
+	}
+
+	diags.Append(data.fromAPI(ctx, r.client.AM.Realm, result)...)
+}
+
+func (m *ValidationModel) fromAPI(ctx context.Context, realm string, validation *am.ValidationService) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(realm)
+	m.ValidGotoDestinations, diags = stringSetValue(ctx, validation.ValidGotoDestinations)
+
+	return diags
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WebhooksDataSource{}
+
+func NewWebhooksDataSource() datasource.DataSource {
+	return &WebhooksDataSource{}
+}
+
+// WebhooksDataSource defines the data source implementation.
+type WebhooksDataSource struct {
+	client *FRAMClient
+}
+
+// WebhooksDataSourceModel describes the data source data model.
+type WebhooksDataSourceModel struct {
+	Webhooks []WebhookSummaryModel `tfsdk:"webhooks"`
+}
+
+// WebhookSummaryModel describes one webhook of the data source. Header
+// values are left out because they usually carry credentials.
+type WebhookSummaryModel struct {
+	Name        types.String `tfsdk:"name"`
+	URL         types.String `tfsdk:"url"`
+	Body        types.String `tfsdk:"body"`
+	HeaderNames types.Set    `tfsdk:"header_names"`
+}
+
+func (d *WebhooksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhooks"
+}
+
+func (d *WebhooksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the webhooks of the Webhook service in the provider realm.",
+
+		Attributes: map[string]schema.Attribute{
+			"webhooks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The webhooks, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name journey nodes use to reference the webhook.",
+						},
+						"url": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URL AM calls.",
+						},
+						"body": schema.StringAttribute{
+							Computed:    true,
+							Description: "The body template sent with the request.",
+						},
+						"header_names": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "The names of the HTTP headers sent with the request. Header values are not exposed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WebhooksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FRAMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FRAMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WebhooksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WebhooksDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhooks, err := d.client.AM.ListWebhooks(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list webhooks, got error: %s", err))
+		return
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+
+	data.Webhooks = make([]WebhookSummaryModel, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		names := make([]string, 0, len(webhook.Headers))
+		for name := range webhook.Headers {
+			names = append(names, name)
+		}
+		headerNames, diags := stringSetValue(ctx, names)
+		resp.Diagnostics.Append(diags...)
+
+		data.Webhooks = append(data.Webhooks, WebhookSummaryModel{
+			Name:        types.StringValue(webhook.ID),
+			URL:         types.StringValue(webhook.URL),
+			Body:        types.StringValue(webhook.Body),
+			HeaderNames: headerNames,
+		})
+	}
+
+	tflog.Trace(ctx, "read a webhooks data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
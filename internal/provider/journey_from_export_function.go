@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JourneyFromExportFunction{}
+
+func NewJourneyFromExportFunction() function.Function {
+	return &JourneyFromExportFunction{}
+}
+
+// JourneyFromExportFunction defines the journey_from_export function
+// implementation.
+type JourneyFromExportFunction struct{}
+
+// JourneyExportModel is the result of journey_from_export.
+type JourneyExportModel struct {
+	Journey       JourneyModel   `tfsdk:"journey"`
+	InnerJourneys []JourneyModel `tfsdk:"inner_journeys"`
+	Scripts       []ScriptModel  `tfsdk:"scripts"`
+}
+
+// JourneyModel describes a journey with the configuration of its nodes. It
+// extends JourneySummaryModel with what is needed to recreate the journey.
+type JourneyModel struct {
+	ID               types.String       `tfsdk:"id"`
+	Description      types.String       `tfsdk:"description"`
+	Enabled          types.Bool         `tfsdk:"enabled"`
+	EntryNodeID      types.String       `tfsdk:"entry_node_id"`
+	IdentityResource types.String       `tfsdk:"identity_resource"`
+	InnerTreeOnly    types.Bool         `tfsdk:"inner_tree_only"`
+	UIConfig         types.Map          `tfsdk:"ui_config"`
+	Nodes            []JourneyNodeModel `tfsdk:"nodes"`
+	PageNodes        []PageNodeModel    `tfsdk:"page_nodes"`
+}
+
+// JourneyNodeModel describes a node placed in a journey.
+type JourneyNodeModel struct {
+	ID          types.String  `tfsdk:"id"`
+	NodeType    types.String  `tfsdk:"node_type"`
+	DisplayName types.String  `tfsdk:"display_name"`
+	Connections types.Map     `tfsdk:"connections"`
+	X           types.Float64 `tfsdk:"x"`
+	Y           types.Float64 `tfsdk:"y"`
+	Config      types.String  `tfsdk:"config"`
+}
+
+// PageNodeModel describes a node placed inside a Page node.
+type PageNodeModel struct {
+	ID          types.String `tfsdk:"id"`
+	PageNodeID  types.String `tfsdk:"page_node_id"`
+	NodeType    types.String `tfsdk:"node_type"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Config      types.String `tfsdk:"config"`
+}
+
+var journeyNodeAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"node_type":    types.StringType,
+	"display_name": types.StringType,
+	"connections":  types.MapType{ElemType: types.StringType},
+	"x":            types.Float64Type,
+	"y":            types.Float64Type,
+	"config":       types.StringType,
+}
+
+var pageNodeAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"page_node_id": types.StringType,
+	"node_type":    types.StringType,
+	"display_name": types.StringType,
+	"config":       types.StringType,
+}
+
+var journeyAttrTypes = map[string]attr.Type{
+	"id":                types.StringType,
+	"description":       types.StringType,
+	"enabled":           types.BoolType,
+	"entry_node_id":     types.StringType,
+	"identity_resource": types.StringType,
+	"inner_tree_only":   types.BoolType,
+	"ui_config":         types.MapType{ElemType: types.StringType},
+	"nodes":             types.ListType{ElemType: types.ObjectType{AttrTypes: journeyNodeAttrTypes}},
+	"page_nodes":        types.ListType{ElemType: types.ObjectType{AttrTypes: pageNodeAttrTypes}},
+}
+
+var scriptAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"name":        types.StringType,
+	"description": types.StringType,
+	"language":    types.StringType,
+	"context":     types.StringType,
+	"default":     types.BoolType,
+	"source":      types.StringType,
+}
+
+func (f *JourneyFromExportFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "journey_from_export"
+}
+
+func (f *JourneyFromExportFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a Frodo journey export",
+		MarkdownDescription: "Parses a single-journey export written by `frodo journey export` into objects shaped like the journeys of `fram_journeys`, " +
+			"extended with node positions, connections and configuration, the nodes inside Page nodes, the inner journeys included in the export and the scripts the nodes use. " +
+			"Node configurations are canonical JSON without `_id`, `_type`, `_outcomes` and `_rev`; script sources are decoded. " +
+			"The provider has no journey resource yet, so the result is meant for reviewing exports and generating configuration ahead of one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The content of the export file, for example `file(\"journeys/Login.journey.json\")`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"journey":        types.ObjectType{AttrTypes: journeyAttrTypes},
+				"inner_journeys": types.ListType{ElemType: types.ObjectType{AttrTypes: journeyAttrTypes}},
+				"scripts":        types.ListType{ElemType: types.ObjectType{AttrTypes: scriptAttrTypes}},
+			},
+		},
+	}
+}
+
+func (f *JourneyFromExportFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data))
+	if resp.Error != nil {
+		return
+	}
+
+	journeys, err := am.ParseFrodoJourneys([]byte(data))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	root, err := exportRoot(journeys)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var result JourneyExportModel
+	scripts := map[string]am.Script{}
+	ids := make([]string, 0, len(journeys))
+	for id := range journeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		j := journeys[id]
+		model, err := journeyValue(j)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, err.Error())
+			return
+		}
+		if id == root {
+			result.Journey = model
+		} else {
+			result.InnerJourneys = append(result.InnerJourneys, model)
+		}
+		for scriptID, s := range j.Scripts {
+			scripts[scriptID] = s
+		}
+	}
+	if result.InnerJourneys == nil {
+		result.InnerJourneys = []JourneyModel{}
+	}
+
+	scriptIDs := make([]string, 0, len(scripts))
+	for id := range scripts {
+		scriptIDs = append(scriptIDs, id)
+	}
+	sort.Strings(scriptIDs)
+
+	result.Scripts = make([]ScriptModel, 0, len(scriptIDs))
+	for _, id := range scriptIDs {
+		s := scripts[id]
+		source, err := s.Source()
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to decode script %q: %s", id, err))
+			return
+		}
+		result.Scripts = append(result.Scripts, ScriptModel{
+			ID:          types.StringValue(id),
+			Name:        types.StringValue(s.Name),
+			Description: types.StringValue(s.Description),
+			Language:    types.StringValue(s.Language),
+			Context:     types.StringValue(s.Context),
+			Default:     types.BoolValue(s.Default),
+			Source:      types.StringValue(source),
+		})
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// exportRoot returns the journey of a single-journey export that is not run
+// as an inner journey by another journey of the export.
+func exportRoot(journeys map[string]*am.FrodoJourney) (string, error) {
+	inner := map[string]bool{}
+	for _, j := range journeys {
+		for _, id := range j.InnerJourneys() {
+			inner[id] = true
+		}
+	}
+
+	var roots []string
+	for id := range journeys {
+		if !inner[id] {
+			roots = append(roots, id)
+		}
+	}
+	sort.Strings(roots)
+
+	if len(roots) != 1 {
+		return "", fmt.Errorf("expected a single-journey export, found the top-level journeys %s", strings.Join(roots, ", "))
+	}
+	return roots[0], nil
+}
+
+func journeyValue(j *am.FrodoJourney) (JourneyModel, error) {
+	uiConfig := j.Tree.UIConfig
+	if uiConfig == nil {
+		uiConfig = map[string]string{}
+	}
+	model := JourneyModel{
+		ID:               types.StringValue(j.Tree.ID),
+		Description:      types.StringValue(j.Tree.Description),
+		Enabled:          types.BoolValue(j.Tree.IsEnabled()),
+		EntryNodeID:      types.StringValue(j.Tree.EntryNodeID),
+		IdentityResource: types.StringValue(j.Tree.IdentityResource),
+		InnerTreeOnly:    types.BoolValue(j.Tree.InnerTreeOnly),
+		UIConfig:         types.MapValueMust(types.StringType, stringMapElements(uiConfig)),
+		Nodes:            []JourneyNodeModel{},
+		PageNodes:        []PageNodeModel{},
+	}
+
+	ids := make([]string, 0, len(j.Tree.Nodes))
+	for id := range j.Tree.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		node := j.Tree.Nodes[id]
+		config, err := nodeConfigValue(j, id)
+		if err != nil {
+			return model, err
+		}
+		connections := node.Connections
+		if connections == nil {
+			connections = map[string]string{}
+		}
+		x, _ := node.X.Float64()
+		y, _ := node.Y.Float64()
+		model.Nodes = append(model.Nodes, JourneyNodeModel{
+			ID:          types.StringValue(id),
+			NodeType:    types.StringValue(node.NodeType),
+			DisplayName: types.StringValue(node.DisplayName),
+			Connections: types.MapValueMust(types.StringType, stringMapElements(connections)),
+			X:           types.Float64Value(x),
+			Y:           types.Float64Value(y),
+			Config:      config,
+		})
+
+		raw, ok := j.Nodes[id]
+		if node.NodeType != "PageNode" || !ok {
+			continue
+		}
+		var page struct {
+			Nodes []struct {
+				ID          string `json:"_id"`
+				NodeType    string `json:"nodeType"`
+				DisplayName string `json:"displayName"`
+			} `json:"nodes"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return model, fmt.Errorf("unable to parse Page node %q: %w", id, err)
+		}
+		for _, child := range page.Nodes {
+			config, err := nodeConfigValue(j, child.ID)
+			if err != nil {
+				return model, err
+			}
+			nodeType := child.NodeType
+			if nodeType == "" {
+				nodeType = am.NodeTypeOf(j.InnerNodes[child.ID])
+			}
+			model.PageNodes = append(model.PageNodes, PageNodeModel{
+				ID:          types.StringValue(child.ID),
+				PageNodeID:  types.StringValue(id),
+				NodeType:    types.StringValue(nodeType),
+				DisplayName: types.StringValue(child.DisplayName),
+				Config:      config,
+			})
+		}
+	}
+
+	return model, nil
+}
+
+// nodeConfigValue returns the configuration of a node as canonical JSON, or
+// null when the export does not include it.
+func nodeConfigValue(j *am.FrodoJourney, id string) (types.String, error) {
+	config, ok, err := j.NodeConfig(id)
+	if err != nil || !ok {
+		return types.StringNull(), err
+	}
+	b, err := am.CanonicalJSON(config)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(string(b)), nil
+}
+
+func stringMapElements(m map[string]string) map[string]attr.Value {
+	elements := make(map[string]attr.Value, len(m))
+	for k, v := range m {
+		elements[k] = types.StringValue(v)
+	}
+	return elements
+}
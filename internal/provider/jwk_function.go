@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JWKFromPEMFunction{}
+var _ function.Function = &JWKSFunction{}
+
+func NewJWKFromPEMFunction() function.Function {
+	return &JWKFromPEMFunction{}
+}
+
+func NewJWKSFunction() function.Function {
+	return &JWKSFunction{}
+}
+
+// JWKFromPEMFunction defines the jwk_from_pem function implementation.
+type JWKFromPEMFunction struct{}
+
+// JWKSFunction defines the jwks function implementation.
+type JWKSFunction struct{}
+
+// privateJWKMembers are the members that carry private or symmetric key
+// material, which must never be published in a JWKS.
+var privateJWKMembers = []string{"d", "p", "q", "dp", "dq", "qi", "oth", "k"}
+
+func (f *JWKFromPEMFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jwk_from_pem"
+}
+
+func (f *JWKFromPEMFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a PEM key or certificate to a JWK",
+		MarkdownDescription: "Returns the public JWK of an RSA, EC (P-256, P-384, P-521) or Ed25519 key as JSON. " +
+			"The PEM data may hold a public key, a private key, of which only the public half is used, or a certificate chain, which is published in `x5c` together with the `x5t` and `x5t#S256` thumbprints. " +
+			"`alg` is set to `RS256`, `ES256`, `ES384`, `ES512` or `EdDSA`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pem",
+				MarkdownDescription: "The PEM data, for example `tls_self_signed_cert.client.cert_pem` or `tls_private_key.client.public_key_pem`.",
+			},
+			function.StringParameter{
+				Name:                "kid",
+				MarkdownDescription: "The key ID. Pass `\"\"` to use the RFC 7638 SHA-256 thumbprint of the key.",
+			},
+			function.StringParameter{
+				Name:                "use",
+				MarkdownDescription: "`sig`, `enc`, or `\"\"` to leave `use` out.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JWKFromPEMFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pemData, kid, use string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pemData, &kid, &use))
+	if resp.Error != nil {
+		return
+	}
+
+	if use != "" && use != "sig" && use != "enc" {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("use must be \"sig\", \"enc\" or \"\", got %q", use))
+		return
+	}
+
+	key, err := am.JWKFromPEM([]byte(pemData))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if kid == "" {
+		kid, err = key.Thumbprint()
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+	key.KeyID = kid
+	key.Use = use
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(b)))
+}
+
+func (f *JWKSFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jwks"
+}
+
+func (f *JWKSFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Assemble a JWKS",
+		MarkdownDescription: "Returns a JSON Web Key Set holding the given JWKs, in order, for example to set the JWKS of an OAuth 2.0 client using `private_key_jwt`. " +
+			"Fails if a key has no `kty`, carries private key material or reuses the `kid` of another key.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				ElementType:         types.StringType,
+				Name:                "keys",
+				MarkdownDescription: "The JWKs as JSON, for example results of `jwk_from_pem`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JWKSFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keys []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &keys))
+	if resp.Error != nil {
+		return
+	}
+
+	set := struct {
+		Keys []json.RawMessage `json:"keys"`
+	}{Keys: make([]json.RawMessage, 0, len(keys))}
+	kids := map[string]int{}
+
+	for i, k := range keys {
+		var members map[string]json.RawMessage
+		var key am.JWK
+		if err := json.Unmarshal([]byte(k), &members); err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("key %d is not a JSON object: %s", i, err))
+			return
+		}
+		if err := json.Unmarshal([]byte(k), &key); err != nil || key.KeyType == "" {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("key %d is not a JWK: it has no kty", i))
+			return
+		}
+		for _, m := range privateJWKMembers {
+			if _, ok := members[m]; ok {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("key %d carries private key material (%q); publish public keys only", i, m))
+				return
+			}
+		}
+		if key.KeyID != "" {
+			if j, ok := kids[key.KeyID]; ok {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("keys %d and %d share the kid %q", j, i, key.KeyID))
+				return
+			}
+			kids[key.KeyID] = i
+		}
+
+		set.Keys = append(set.Keys, json.RawMessage(compactJSON([]byte(k))))
+	}
+
+	b, err := json.Marshal(set)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(b)))
+}
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The CLI subcommands read resources from AM without Terraform. They run the
+// ImportState and Read methods of the resources in process, so what they see
+// is exactly what an import or refresh would store.
+
+// LiveResource is a resource read from AM.
+type LiveResource struct {
+	TypeName string
+	// ImportID is the identifier `terraform import` accepts for the resource.
+	ImportID string
+	Schema   schema.Schema
+	State    tftypes.Value
+}
+
+type liveResourceType struct {
+	new func() resource.Resource
+	// importIDs lists the import identifiers of the objects of the realm.
+	importIDs func(ctx context.Context, client *FRAMClient) ([]string, error)
+	// global is set for objects that are not part of a realm.
+	global bool
+}
+
+// liveResourceTypes are the resource types that can be listed from AM. Users
+// are left out, as their passwords cannot be read back, and so are the server
+// and site resources, which are not part of a realm.
+var liveResourceTypes = map[string]liveResourceType{
+	"fram_auth_module": {new: NewAuthModuleResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		modules, err := client.AM.ListAuthModules(ctx)
+		ids := make([]string, len(modules))
+		for i, m := range modules {
+			ids[i] = m.Type + "/" + m.ID
+		}
+		return ids, err
+	}},
+	"fram_auth_chain": {new: NewAuthChainResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		chains, err := client.AM.ListAuthChains(ctx)
+		ids := make([]string, len(chains))
+		for i, c := range chains {
+			ids[i] = c.ID
+		}
+		return ids, err
+	}},
+	"fram_cors": {new: NewCorsResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		configs, err := client.AM.ListCorsConfigurations(ctx)
+		ids := make([]string, len(configs))
+		for i, c := range configs {
+			ids[i] = c.ID
+		}
+		return ids, err
+	}, global: true},
+	"fram_group": {new: NewGroupResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		groups, err := client.AM.QueryIdentities(ctx, client.AM.Realm, "groups", am.QueryParams{Fields: []string{"_id"}})
+		ids := make([]string, len(groups))
+		for i, g := range groups {
+			ids[i] = g.ID
+		}
+		return ids, err
+	}},
+	"fram_session": {new: NewSessionResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		return []string{client.AM.Realm}, nil
+	}},
+	"fram_validation": {new: NewValidationResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		return []string{client.AM.Realm}, nil
+	}},
+	"fram_webhook": {new: NewWebhookResource, importIDs: func(ctx context.Context, client *FRAMClient) ([]string, error) {
+		webhooks, err := client.AM.ListWebhooks(ctx)
+		ids := make([]string, len(webhooks))
+		for i, w := range webhooks {
+			ids[i] = w.ID
+		}
+		return ids, err
+	}},
+}
+
+// WithRealm returns a client that reads realm with the AM session of c, so
+// that the CLI signs in once however many realms it reads. The fram-client-go
+// client, which the live resource types do not use, is shared as it is.
+func (c *FRAMClient) WithRealm(realm string) (*FRAMClient, error) {
+	amClient, err := c.AM.WithRealm(realm)
+	if err != nil {
+		return nil, err
+	}
+	return &FRAMClient{Client: c.Client, AM: amClient}, nil
+}
+
+// LiveResourceTypes returns the resource types ListLiveResources supports, in
+// alphabetical order.
+func LiveResourceTypes() []string {
+	names := make([]string, 0, len(liveResourceTypes))
+	for t := range liveResourceTypes {
+		names = append(names, t)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsGlobalResourceType reports whether the objects of typeName are shared by
+// all realms rather than part of one.
+func IsGlobalResourceType(typeName string) bool {
+	return liveResourceTypes[typeName].global
+}
+
+// ListLiveResourceIDs returns the import identifiers of every object of
+// typeName in the realm of client, in alphabetical order. Singleton services
+// are returned whether or not the realm has them.
+func ListLiveResourceIDs(ctx context.Context, client *FRAMClient, typeName string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	t, ok := liveResourceTypes[typeName]
+	if !ok {
+		diags.AddError("Unsupported Resource Type", fmt.Sprintf("%s cannot be read from AM.", typeName))
+		return nil, diags
+	}
+
+	ids, err := t.importIDs(ctx, client)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list %s objects, got error: %s", typeName, err))
+		return nil, diags
+	}
+	sort.Strings(ids)
+	return ids, diags
+}
+
+// ListLiveResources reads every object of typeName in the realm of client.
+// Singleton services the realm does not have are left out.
+func ListLiveResources(ctx context.Context, client *FRAMClient, typeName string) ([]LiveResource, diag.Diagnostics) {
+	ids, diags := ListLiveResourceIDs(ctx, client, typeName)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var result []LiveResource
+	for _, id := range ids {
+		live, d := importLiveResource(ctx, client, typeName, liveResourceTypes[typeName].new(), id)
+		diags.Append(d...)
+		if d.HasError() {
+			return result, diags
+		}
+		if live != nil {
+			result = append(result, *live)
+		}
+	}
+	return result, diags
+}
+
+// RefreshLiveResource reads the object a resource instance of a state file
+// describes, as a refresh would, and returns the instance as recorded and as
+// read. attributes are the attributes of the instance in the state file;
+// attributes the current schema no longer has are ignored. live is nil when
+// the object no longer exists.
+func RefreshLiveResource(ctx context.Context, client *FRAMClient, typeName string, attributes json.RawMessage) (prior, live *LiveResource, diags diag.Diagnostics) {
+	var r resource.Resource
+	for _, newResource := range New("cli")().Resources(ctx) {
+		candidate := newResource()
+		var resp resource.MetadataResponse
+		candidate.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "fram"}, &resp)
+		if resp.TypeName == typeName {
+			r = candidate
+		}
+	}
+	if r == nil {
+		diags.AddError("Unsupported Resource Type", fmt.Sprintf("The provider has no resource type %s.", typeName))
+		return nil, nil, diags
+	}
+
+	s := liveSchema(ctx, client, r, &diags)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+	raw, err := tfprotov6.RawState{JSON: attributes}.UnmarshalWithOpts(s.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+	if err != nil {
+		diags.AddError("Invalid State", fmt.Sprintf("Unable to read the %s attributes of the state file, got error: %s", typeName, err))
+		return nil, nil, diags
+	}
+
+	state := tfsdk.State{Schema: s, Raw: raw}
+	var id string
+	diags.Append(state.GetAttribute(ctx, path.Root("id"), &id)...)
+	prior = &LiveResource{TypeName: typeName, ImportID: id, Schema: s, State: raw}
+
+	refreshed := readLiveResource(ctx, r, state, &diags)
+	if diags.HasError() || refreshed.Raw.IsNull() {
+		return prior, nil, diags
+	}
+	return prior, &LiveResource{TypeName: typeName, ImportID: id, Schema: s, State: refreshed.Raw}, diags
+}
+
+// importLiveResource imports id into an empty state and reads it, as
+// `terraform import` does. It returns nil when the object does not exist.
+func importLiveResource(ctx context.Context, client *FRAMClient, typeName string, r resource.Resource, id string) (*LiveResource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	s := liveSchema(ctx, client, r, &diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		diags.AddError("Unsupported Resource Type", fmt.Sprintf("%s cannot be imported.", typeName))
+		return nil, diags
+	}
+
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+	diags.Append(importResp.Diagnostics...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	state := readLiveResource(ctx, r, importResp.State, &diags)
+	if diags.HasError() || state.Raw.IsNull() {
+		return nil, diags
+	}
+	return &LiveResource{TypeName: typeName, ImportID: id, Schema: s, State: state.Raw}, diags
+}
+
+// liveSchema configures r with client and returns its schema.
+func liveSchema(ctx context.Context, client *FRAMClient, r resource.Resource, diags *diag.Diagnostics) schema.Schema {
+	if c, ok := r.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		c.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	diags.Append(resp.Diagnostics...)
+	return resp.Schema
+}
+
+// readLiveResource refreshes state through the Read method of r. A resource
+// that no longer exists comes back with a null state.
+func readLiveResource(ctx context.Context, r resource.Resource, state tfsdk.State, diags *diag.Diagnostics) tfsdk.State {
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	diags.Append(resp.Diagnostics...)
+	return resp.State
+}
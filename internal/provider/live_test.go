@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/darkedges/terraform-provider-fram/internal/fakeam"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestClient returns a client for a fake AM that serves objects.
+func newTestClient(t *testing.T, objects map[string]string) *FRAMClient {
+	t.Helper()
+	client, diags := NewFRAMClient(FRAMProviderModel{Host: types.StringValue(fakeam.New(t, objects))})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return client
+}
+
+func TestListLiveResourcesAuthModule(t *testing.T) {
+	module := `{"_id": "Corporate", "_type": {"_id": "ldap"}, "authenticationLevel": 2, "primaryLdapServer": ["ldap.example.com:389"], "userBindDN": "cn=admin"}`
+	client := newTestClient(t, map[string]string{
+		"/realms/root/realm-config/authentication/modules":                `{"result": [` + module + `]}`,
+		"/realms/root/realm-config/authentication/modules/ldap/Corporate": module,
+	})
+	ctx := context.Background()
+
+	resources, diags := ListLiveResources(ctx, client, "fram_auth_module")
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(resources) != 1 || resources[0].ImportID != "ldap/Corporate" {
+		t.Fatalf("ListLiveResources = %+v, want module ldap/Corporate", resources)
+	}
+
+	var data AuthModuleModel
+	state := tfsdk.State{Schema: resources[0].Schema, Raw: resources[0].State}
+	if diags := state.Get(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var settings map[string]string
+	data.Settings.ElementsAs(ctx, &settings, false)
+	want := map[string]string{"primaryLdapServer": `["ldap.example.com:389"]`, "userBindDN": `"cn=admin"`}
+	if fmt.Sprint(settings) != fmt.Sprint(want) {
+		t.Errorf("settings = %v, want %v", settings, want)
+	}
+	if data.AuthenticationLevel.ValueInt64() != 2 {
+		t.Errorf("authentication_level = %s, want 2", data.AuthenticationLevel)
+	}
+}
+
+func TestImportUserWarnsAboutAttributes(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/realms/root/users/demo": `{"_id": "demo", "username": "demo", "mail": ["demo@example.com"], "inetUserStatus": ["Active"], "givenName": ["Demo"]}`,
+	})
+
+	live, diags := importLiveResource(context.Background(), client, "fram_user", NewUserResource(), "demo")
+	if diags.HasError() || live == nil {
+		t.Fatalf("importLiveResource = %v, %v", live, diags)
+	}
+	want := diag.NewAttributeWarningDiagnostic(path.Root("attributes"), "Attributes Not Imported",
+		"The custom attributes of the user are not imported. Add the ones to manage to attributes in the configuration.")
+	if !diags.Contains(want) {
+		t.Errorf("diagnostics = %v, want the attributes warning", diags)
+	}
+}
@@ -5,16 +5,26 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/darkedges/fram-client-go/fram"
+	"github.com/darkedges/terraform-provider-fram/internal/am"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"os"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &FRAMProvider{}
+var _ provider.ProviderWithFunctions = &FRAMProvider{}
+var _ provider.ProviderWithEphemeralResources = &FRAMProvider{}
 
 // FRAMProvider defines the provider implementation.
 type FRAMProvider struct {
@@ -30,10 +40,34 @@ type FRAMProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	Realm    types.String `tfsdk:"realm"`
+
+	AuthJourney types.String `tfsdk:"auth_journey"`
+	TOTPSecret  types.String `tfsdk:"totp_secret"`
+}
+
+// FRAMClient is handed to resources and data sources as provider data. It
+// embeds the fram-client-go client used by the Base URL Source resources and
+// adds the REST client used for everything else.
+type FRAMClient struct {
+	*fram.Client
+	AM *am.Client
+}
+
+// checkBaseURLSourceAuth reports an error when the provider signs in through
+// auth_journey. The base URL source is managed through fram-client-go, which
+// only signs in with a username and password, so it would fail where the
+// journey enforces MFA.
+func checkBaseURLSourceAuth(client *FRAMClient, typeName string, diags *diag.Diagnostics) {
+	if client == nil || client.AM.Auth.Journey == "" {
+		return
+	}
+	diags.AddError("Unsupported Authentication",
+		fmt.Sprintf("%s cannot be used with auth_journey, as it signs in with the username and password only. "+
+			"Configure it from a provider without auth_journey.", typeName))
 }
 
 func (p *FRAMProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
-	resp.TypeName = "baseurlsource"
+	resp.TypeName = "fram"
 	resp.Version = p.version
 }
 
@@ -41,21 +75,32 @@ func (p *FRAMProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "FRAM Host to connect as, must include the application context i.e `https://internal.example.com/openam`.<BR>The default is `http://localhost:8080/openam`",
+				MarkdownDescription: "FRAM Host to connect as, must include the application context i.e `https://internal.example.com/openam`. Can also be set with the `FRAM_HOST` environment variable.<BR>The default is `http://localhost:8080/openam`",
 				Optional:            true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "FRAM username to connect as.<BR>The default is `amadmin`",
+				MarkdownDescription: "FRAM username to connect as. Can also be set with the `FRAM_USERNAME` environment variable.<BR>The default is `amadmin`",
 				Optional:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "FRAM Password of username to connect as.<BR>The default is `p4ssw0rd`",
+				MarkdownDescription: "FRAM Password of username to connect as. Can also be set with the `FRAM_PASSWORD` environment variable.<BR>The default is `p4ssw0rd`",
 				Optional:            true,
 			},
 			"realm": schema.StringAttribute{
-				MarkdownDescription: "FRAM realm to use i.e `/root`.<BR>The default is `/realm`",
+				MarkdownDescription: "FRAM realm to use i.e `/alpha`. Also accepts `alpha`, `root/alpha` and `/realms/root/realms/alpha`, see `provider::fram::realm_path()`. Can also be set with the `FRAM_REALM` environment variable.<BR>The default is `/`",
+				Validators:          []validator.String{realmValidator{}},
 				Optional:            true,
 			},
+			"auth_journey": schema.StringAttribute{
+				MarkdownDescription: "Journey of the root realm to sign in through, for administrators that must use MFA. `NameCallback` and `PasswordCallback` are answered with `username` and `password`, one-time password prompts with a code generated from `totp_secret`, and choice and confirmation callbacks with their defaults. " +
+					"The provider signs in through the same journey again when the session expires. `fram_baseurlsource` does not support it. Can also be set with the `FRAM_AUTH_JOURNEY` environment variable.<BR>The default is to sign in with the `X-OpenAM-Username` and `X-OpenAM-Password` headers",
+				Optional: true,
+			},
+			"totp_secret": schema.StringAttribute{
+				MarkdownDescription: "Base32 secret, or `otpauth://totp/` URI, of the OATH device registered for `username`, used to answer the one-time password prompt of `auth_journey`. Can also be set with the `FRAM_TOTP_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 		},
 	}
 }
@@ -68,20 +113,125 @@ func (p *FRAMProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	client, _ := fram.NewClient(data.Host.ValueStringPointer(), data.Username.ValueStringPointer(), data.Password.ValueStringPointer(), data.Realm.ValueStringPointer())
-	resp.DataSourceData = client
-	resp.ResourceData = client
+
+	frc, diags := NewFRAMClient(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.DataSourceData = frc
+	resp.ResourceData = frc
+	resp.EphemeralResourceData = frc
+}
+
+// NewFRAMClient creates the clients for the settings of data, applying the
+// same environment variables, defaults and checks whether they come from the
+// provider configuration or from a CLI subcommand.
+func NewFRAMClient(data FRAMProviderModel) (*FRAMClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Settings the configuration leaves out are read from the environment.
+	for env, v := range map[string]*types.String{
+		"FRAM_HOST":         &data.Host,
+		"FRAM_USERNAME":     &data.Username,
+		"FRAM_PASSWORD":     &data.Password,
+		"FRAM_REALM":        &data.Realm,
+		"FRAM_AUTH_JOURNEY": &data.AuthJourney,
+		"FRAM_TOTP_SECRET":  &data.TOTPSecret,
+	} {
+		if v.IsNull() && os.Getenv(env) != "" {
+			*v = types.StringValue(os.Getenv(env))
+		}
+	}
+
+	if !data.Realm.IsNull() {
+		realm, err := am.CanonicalRealm(data.Realm.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("realm"), "Invalid Realm", err.Error())
+			return nil, diags
+		}
+		data.Realm = types.StringValue(realm)
+	}
+
+	client, err := fram.NewClient(data.Host.ValueStringPointer(), data.Username.ValueStringPointer(), data.Password.ValueStringPointer(), data.Realm.ValueStringPointer())
+	if err != nil {
+		diags.AddError("Unable to Create FRAM API Client", err.Error())
+		return nil, diags
+	}
+	amClient, err := am.NewClient(data.Host.ValueStringPointer(), data.Username.ValueStringPointer(), data.Password.ValueStringPointer(), data.Realm.ValueStringPointer())
+	if err != nil {
+		diags.AddError("Unable to Create FRAM API Client", err.Error())
+		return nil, diags
+	}
+	amClient.Auth.Journey = data.AuthJourney.ValueString()
+	if !data.TOTPSecret.IsNull() {
+		if data.AuthJourney.IsNull() {
+			diags.AddAttributeError(path.Root("totp_secret"), "Missing Auth Journey", "totp_secret is only used when signing in through auth_journey.")
+			return nil, diags
+		}
+		if amClient.Auth.TOTP, err = am.ParseTOTPSecret(data.TOTPSecret.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("totp_secret"), "Invalid TOTP Secret", err.Error())
+			return nil, diags
+		}
+	}
+	return &FRAMClient{Client: client, AM: amClient}, diags
 }
 
 func (p *FRAMProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBaseURLSourceResource,
+		NewSessionResource,
+		NewCorsResource,
+		NewValidationResource,
+		NewUserResource,
+		NewGroupResource,
+		NewAuthModuleResource,
+		NewAuthChainResource,
+		NewSiteResource,
+		NewServerResource,
+		NewServerDefaultsResource,
+		NewWebhookResource,
+		NewConfigImportResource,
+		NewAmsterImportResource,
 	}
 }
 
 func (p *FRAMProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewBaseURLSourceDataSource,
+		NewUserDataSource,
+		NewWebhooksDataSource,
+		NewServerInfoDataSource,
+		NewOpenIDConfigurationDataSource,
+		NewJWKSDataSource,
+		NewOAuth2ClientsDataSource,
+		NewScriptsDataSource,
+		NewJourneysDataSource,
+		NewJourneyNodeTypesDataSource,
+		NewSAML2MetadataDataSource,
+		NewPolicyEvaluationDataSource,
+		NewRealmExportDataSource,
+		NewIdentitiesDataSource,
+		NewAmsterExportDataSource,
+	}
+}
+
+func (p *FRAMProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+		NewJourneySessionEphemeralResource,
+	}
+}
+
+func (p *FRAMProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRealmPathFunction,
+		NewRealmAPIPathFunction,
+		NewJourneyFromExportFunction,
+		NewURLPatternMatchesFunction,
+		NewURLPatternValidFunction,
+		NewJWKFromPEMFunction,
+		NewJWKSFunction,
 	}
 }
 
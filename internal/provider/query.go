@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// withQueryAttributes adds the inputs shared by the list data sources to
+// attributes and returns it.
+func withQueryAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["realm"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The realm to query, for example `/alpha`. Defaults to the provider realm.",
+		Validators:  []validator.String{realmValidator{}},
+	}
+	attributes["query_filter"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "A CREST `_queryFilter` expression. Defaults to `true`, which matches everything.",
+	}
+	attributes["fields"] = schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "The fields AM returns (`_fields`). Attributes backed by other fields are left empty. `_id` is always requested.",
+	}
+	attributes["sort_keys"] = schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "The fields to sort by (`_sortKeys`); prefix a field with `-` to sort in descending order.",
+	}
+	return attributes
+}
+
+// queryParams converts the shared inputs into CREST query parameters and
+// resolves the realm, falling back to the provider realm.
+func queryParams(ctx context.Context, client *FRAMClient, realm *types.String, filter types.String, fields, sortKeys types.List) (am.QueryParams, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	params := am.QueryParams{Filter: filter.ValueString()}
+
+	if !fields.IsNull() && !fields.IsUnknown() {
+		diags.Append(fields.ElementsAs(ctx, &params.Fields, false)...)
+		if len(params.Fields) > 0 {
+			params.Fields = append([]string{"_id"}, params.Fields...)
+		}
+	}
+	if !sortKeys.IsNull() && !sortKeys.IsUnknown() {
+		diags.Append(sortKeys.ElementsAs(ctx, &params.SortKeys, false)...)
+	}
+
+	_, d := resolveRealm(client, realm)
+	diags.Append(d...)
+
+	return params, diags
+}
+
+// resolveRealm returns the canonical form of a realm attribute, setting the
+// attribute to the provider realm when it is not configured. A configured
+// realm keeps the form it is written in, as Terraform expects it back as is.
+func resolveRealm(client *FRAMClient, realm *types.String) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if realm.ValueString() == "" {
+		*realm = types.StringValue(client.AM.Realm)
+		return client.AM.Realm, diags
+	}
+	canonical, err := am.CanonicalRealm(realm.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("realm"), "Invalid Realm", err.Error())
+	}
+	return canonical, diags
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RealmPathFunction{}
+var _ function.Function = &RealmAPIPathFunction{}
+
+func NewRealmPathFunction() function.Function {
+	return &RealmPathFunction{}
+}
+
+func NewRealmAPIPathFunction() function.Function {
+	return &RealmAPIPathFunction{}
+}
+
+// RealmPathFunction defines the realm_path function implementation.
+type RealmPathFunction struct{}
+
+// RealmAPIPathFunction defines the realm_api_path function implementation.
+type RealmAPIPathFunction struct{}
+
+// realmParameter accepts a realm in every form ParseRealm understands.
+var realmParameter = function.StringParameter{
+	Name:                "realm",
+	MarkdownDescription: "A realm such as `/`, `alpha`, `/alpha`, `root/alpha` or `/realms/root/realms/alpha`.",
+}
+
+func (f *RealmPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "realm_path"
+}
+
+func (f *RealmPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Canonical realm name",
+		MarkdownDescription: "Returns a realm in the canonical `/alpha` form the provider uses, or `/` for the root realm. The provider resolves its own `realm` setting the same way.",
+		Parameters:          []function.Parameter{realmParameter},
+		Return:              function.StringReturn{},
+	}
+}
+
+func (f *RealmPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var realm string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &realm))
+	if resp.Error != nil {
+		return
+	}
+
+	canonical, err := am.CanonicalRealm(realm)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, canonical))
+}
+
+func (f *RealmAPIPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "realm_api_path"
+}
+
+func (f *RealmAPIPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Realm REST path segment",
+		MarkdownDescription: "Returns the path segment AM expects in realm-scoped REST URLs, for example `/realms/root/realms/alpha` for `/alpha`.",
+		Parameters:          []function.Parameter{realmParameter},
+		Return:              function.StringReturn{},
+	}
+}
+
+func (f *RealmAPIPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var realm string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &realm))
+	if resp.Error != nil {
+		return
+	}
+
+	apiPath, err := am.RealmAPIPath(realm)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, apiPath))
+}
@@ -0,0 +1,486 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serverPropertyTabs are the server property tabs exposed as attributes. The
+// attribute names match the AM tab names.
+var serverPropertyTabs = []string{"general", "security", "session", "sdk", "cts", "advanced"}
+
+// serverPropertiesDescription explains the property tab attributes in the
+// descriptions of the server resources.
+const serverPropertiesDescription = "The general, security, session, SDK and CTS tabs expose their common properties as typed " +
+	"attributes; `advanced` is a map that takes any other property."
+
+// serverPropertyKind is the Terraform type of a typed server property.
+type serverPropertyKind int
+
+const (
+	serverPropertyString serverPropertyKind = iota
+	serverPropertyBool
+	serverPropertyInt64
+)
+
+// serverProperty is a property of a tab exposed as a typed attribute.
+type serverProperty struct {
+	name        string
+	key         string
+	kind        serverPropertyKind
+	description string
+	// values lists the accepted values of a string property, if limited.
+	values []string
+	// sensitive properties are hidden from plans. AM does not return them
+	// in clear text, so the configured value is kept rather than refreshed.
+	sensitive bool
+}
+
+// serverPropertyFields are the typed properties of every tab but advanced,
+// keyed by tab.
+var serverPropertyFields = map[string][]serverProperty{
+	"general": {
+		{name: "debug_level", key: "com.iplanet.services.debug.level", description: "Debug logging level",
+			values: []string{"off", "error", "warning", "message"}},
+		{name: "debug_directory", key: "com.iplanet.services.debug.directory", description: "Directory of the debug logs"},
+		{name: "debug_merge_all", key: "com.iplanet.services.debug.mergeall", description: "Whether to write all debug logs to one file",
+			values: []string{"on", "off"}},
+		{name: "locale", key: "com.iplanet.am.locale", description: "Default locale, for example `en_US`"},
+		{name: "notification_url", key: "com.sun.identity.client.notification.url", description: "URL clients send notifications to"},
+		{name: "xml_validating", key: "com.iplanet.am.util.xml.validating", description: "Whether XML documents are validated",
+			values: []string{"on", "off"}},
+		{name: "smtp_host", key: "com.iplanet.am.smtphost", description: "Mail server host"},
+		{name: "smtp_port", key: "com.iplanet.am.smtpport", kind: serverPropertyInt64, description: "Mail server port"},
+	},
+	"security": {
+		{name: "encryptor", key: "com.iplanet.security.encryptor", description: "Class that encrypts stored secrets"},
+		{name: "cookie_name", key: "com.iplanet.am.cookie.name", description: "Name of the session cookie"},
+		{name: "cookie_secure", key: "com.iplanet.am.cookie.secure", kind: serverPropertyBool, description: "Whether the session cookie is only sent over HTTPS"},
+		{name: "cookie_encode", key: "com.iplanet.am.cookie.encode", kind: serverPropertyBool, description: "Whether the session cookie is URL encoded"},
+		{name: "client_ip_check_enabled", key: "com.iplanet.am.clientIPCheckEnabled", kind: serverPropertyBool,
+			description: "Whether sessions are bound to the client IP address"},
+		{name: "pll_max_content_length", key: "com.iplanet.services.comm.server.pllrequest.maxContentLength", kind: serverPropertyInt64,
+			description: "Largest PLL request accepted, in bytes"},
+		{name: "ocsp_check_enabled", key: "com.sun.identity.authentication.ocspCheck", kind: serverPropertyBool,
+			description: "Whether certificates are checked with OCSP"},
+		{name: "ocsp_responder_url", key: "com.sun.identity.authentication.ocsp.responder.url", description: "URL of the OCSP responder"},
+		{name: "deserialisation_whitelist", key: "openam.deserialisation.classes.whitelist",
+			description: "Comma-separated classes AM may deserialise"},
+	},
+	"session": {
+		{name: "max_sessions", key: "com.iplanet.am.session.maxSessions", kind: serverPropertyInt64, description: "Most sessions the server holds"},
+		{name: "stats_state", key: "com.iplanet.services.stats.state", description: "Where session statistics are written",
+			values: []string{"off", "file", "console"}},
+		{name: "stats_directory", key: "com.iplanet.services.stats.directory", description: "Directory of the session statistics"},
+		{name: "stats_interval", key: "com.iplanet.am.stats.interval", kind: serverPropertyInt64, description: "Seconds between session statistics"},
+		{name: "host_lookup_enabled", key: "com.sun.am.session.enableHostLookUp", kind: serverPropertyBool,
+			description: "Whether client host names are looked up"},
+		{name: "notification_pool_size", key: "com.iplanet.am.notification.threadpool.size", kind: serverPropertyInt64,
+			description: "Threads sending session notifications"},
+		{name: "notification_threshold", key: "com.iplanet.am.notification.threadpool.threshold", kind: serverPropertyInt64,
+			description: "Session notifications queued before new ones are dropped"},
+		{name: "case_insensitive_dn", key: "com.sun.am.session.caseInsensitiveDN", kind: serverPropertyBool,
+			description: "Whether user DNs are compared case-insensitively"},
+	},
+	"sdk": {
+		{name: "datastore_notification_enabled", key: "com.sun.identity.sm.enableDataStoreNotification", kind: serverPropertyBool,
+			description: "Whether the configuration store sends change notifications"},
+		{name: "datastore_notification_pool_size", key: "com.sun.identity.sm.notification.threadpool.size", kind: serverPropertyInt64,
+			description: "Threads handling configuration store notifications"},
+		{name: "event_connection_retries", key: "com.iplanet.am.event.connection.num.retries", kind: serverPropertyInt64,
+			description: "Retries of the persistent search connection"},
+		{name: "event_connection_retry_delay", key: "com.iplanet.am.event.connection.delay.between.retries", kind: serverPropertyInt64,
+			description: "Milliseconds between persistent search retries"},
+		{name: "event_connection_retry_error_codes", key: "com.iplanet.am.event.connection.ldap.error.codes.retries",
+			description: "Comma-separated LDAP error codes that make persistent searches retry"},
+		{name: "event_connection_disabled", key: "com.sun.am.event.connection.disable.list",
+			description: "Comma-separated persistent searches to disable, out of `aci`, `um` and `sm`"},
+		{name: "ldap_connection_retries", key: "com.iplanet.am.ldap.connection.num.retries", kind: serverPropertyInt64,
+			description: "Retries of LDAP connections"},
+		{name: "ldap_connection_retry_delay", key: "com.iplanet.am.ldap.connection.delay.between.retries", kind: serverPropertyInt64,
+			description: "Milliseconds between LDAP connection retries"},
+		{name: "ldap_connection_retry_error_codes", key: "com.iplanet.am.ldap.connection.ldap.error.codes.retries",
+			description: "Comma-separated LDAP error codes that make connections retry"},
+		{name: "cache_max_size", key: "com.iplanet.am.sdk.cache.maxSize", kind: serverPropertyInt64, description: "Most entries of the SDK cache"},
+		{name: "cache_expire_enabled", key: "com.iplanet.am.sdk.cache.entry.expire.enabled", kind: serverPropertyBool,
+			description: "Whether SDK cache entries expire"},
+		{name: "cache_user_expire_time", key: "com.iplanet.am.sdk.cache.entry.user.expire.time", kind: serverPropertyInt64,
+			description: "Minutes before cached user entries expire"},
+		{name: "cache_default_expire_time", key: "com.iplanet.am.sdk.cache.entry.default.expire.time", kind: serverPropertyInt64,
+			description: "Minutes before other cached entries expire"},
+	},
+	"cts": {
+		{name: "store_location", key: "org.forgerock.services.cts.store.location", description: "Whether the CTS uses the configuration store or an external one",
+			values: []string{"default", "external"}},
+		{name: "root_suffix", key: "org.forgerock.services.cts.store.root.suffix", description: "Base DN of the CTS entries"},
+		{name: "max_connections", key: "org.forgerock.services.cts.store.max.connections", kind: serverPropertyInt64,
+			description: "Most connections to the CTS store"},
+		{name: "page_size", key: "org.forgerock.services.cts.store.page.size", kind: serverPropertyInt64, description: "Page size of CTS searches"},
+		{name: "vlv_page_size", key: "org.forgerock.services.cts.store.vlv.page.size", kind: serverPropertyInt64,
+			description: "Page size of CTS searches that use a VLV index"},
+		{name: "directory_name", key: "org.forgerock.services.cts.store.directory.name",
+			description: "Comma-separated `host:port` of the external CTS store"},
+		{name: "ssl_enabled", key: "org.forgerock.services.cts.store.ssl.enabled", kind: serverPropertyBool,
+			description: "Whether the external CTS store is reached over LDAPS"},
+		{name: "login_id", key: "org.forgerock.services.cts.store.loginid", description: "Bind DN of the external CTS store"},
+		{name: "password", key: "org.forgerock.services.cts.store.password", description: "Bind password of the external CTS store",
+			sensitive: true},
+		{name: "heartbeat", key: "org.forgerock.services.cts.store.heartbeat", kind: serverPropertyInt64,
+			description: "Seconds between heartbeats on CTS connections"},
+		{name: "affinity_enabled", key: "org.forgerock.services.cts.store.affinity.enabled", kind: serverPropertyBool,
+			description: "Whether requests for a token always go to the same CTS server"},
+	},
+}
+
+// serverPropertiesModel holds the property tabs of the server resources.
+type serverPropertiesModel struct {
+	General  types.Object `tfsdk:"general"`
+	Security types.Object `tfsdk:"security"`
+	Session  types.Object `tfsdk:"session"`
+	SDK      types.Object `tfsdk:"sdk"`
+	CTS      types.Object `tfsdk:"cts"`
+	Advanced types.Map    `tfsdk:"advanced"`
+}
+
+func (m *serverPropertiesModel) tab(name string) attr.Value {
+	switch name {
+	case "general":
+		return m.General
+	case "security":
+		return m.Security
+	case "session":
+		return m.Session
+	case "sdk":
+		return m.SDK
+	case "cts":
+		return m.CTS
+	}
+	return m.Advanced
+}
+
+func (m *serverPropertiesModel) setTab(name string, value attr.Value) {
+	switch v := value.(type) {
+	case types.Map:
+		m.Advanced = v
+	case types.Object:
+		switch name {
+		case "general":
+			m.General = v
+		case "security":
+			m.Security = v
+		case "session":
+			m.Session = v
+		case "sdk":
+			m.SDK = v
+		case "cts":
+			m.CTS = v
+		}
+	}
+}
+
+// serverPropertyAttributes returns one attribute per property tab. perServer
+// switches the descriptions between the server defaults and a single server's
+// overrides.
+func serverPropertyAttributes(perServer bool) map[string]schema.Attribute {
+	attrs := map[string]schema.Attribute{}
+	for _, tab := range serverPropertyTabs {
+		if tab == "advanced" {
+			description := "Advanced properties keyed by property name, for example `com.iplanet.am.lbcookie.value`."
+			if perServer {
+				description += " The map is authoritative: advanced properties set on the server but not listed here show up as drift and are removed on apply."
+			} else {
+				description += " Only the properties listed here are checked for drift."
+			}
+			attrs[tab] = schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: description,
+			}
+			continue
+		}
+
+		description := fmt.Sprintf("Properties of the %s tab. Only the properties set here are checked for drift.", tab)
+		if perServer {
+			description = fmt.Sprintf("Properties of the %s tab that this server overrides. "+
+				"Removing a property, or the whole attribute, makes the server inherit the server default again.", tab)
+		}
+		fields := map[string]schema.Attribute{}
+		for _, f := range serverPropertyFields[tab] {
+			fieldDescription := fmt.Sprintf("%s, the `%s` property.", f.description, f.key)
+			if f.values != nil {
+				fieldDescription = fmt.Sprintf("%s, the `%s` property: one of `%s` or `%s`.", f.description, f.key,
+					strings.Join(f.values[:len(f.values)-1], "`, `"), f.values[len(f.values)-1])
+			}
+			switch f.kind {
+			case serverPropertyBool:
+				fields[f.name] = schema.BoolAttribute{Optional: true, MarkdownDescription: fieldDescription}
+			case serverPropertyInt64:
+				fields[f.name] = schema.Int64Attribute{Optional: true, MarkdownDescription: fieldDescription}
+			default:
+				var validators []validator.String
+				if f.values != nil {
+					validators = append(validators, stringOneOfValidator{values: f.values})
+				}
+				fields[f.name] = schema.StringAttribute{
+					Optional:            true,
+					Sensitive:           f.sensitive,
+					MarkdownDescription: fieldDescription,
+					Validators:          validators,
+				}
+			}
+		}
+		attrs[tab] = schema.SingleNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: description,
+			Attributes:          fields,
+		}
+	}
+	return attrs
+}
+
+// serverPropertyTypes returns the attribute types of a typed tab.
+func serverPropertyTypes(tab string) map[string]attr.Type {
+	attrTypes := map[string]attr.Type{}
+	for _, f := range serverPropertyFields[tab] {
+		switch f.kind {
+		case serverPropertyBool:
+			attrTypes[f.name] = types.BoolType
+		case serverPropertyInt64:
+			attrTypes[f.name] = types.Int64Type
+		default:
+			attrTypes[f.name] = types.StringType
+		}
+	}
+	return attrTypes
+}
+
+// tabProperties returns the properties a tab attribute sets, keyed by AM
+// property name and rendered as am.PropertyString does. It returns nil for a
+// null or unknown tab.
+func tabProperties(ctx context.Context, tab string, value attr.Value) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	props := map[string]string{}
+	switch v := value.(type) {
+	case types.Map:
+		diags.Append(v.ElementsAs(ctx, &props, false)...)
+	case types.Object:
+		fields := v.Attributes()
+		for _, f := range serverPropertyFields[tab] {
+			field := fields[f.name]
+			if field == nil || field.IsNull() || field.IsUnknown() {
+				continue
+			}
+			switch field := field.(type) {
+			case types.String:
+				props[f.key] = field.ValueString()
+			case types.Bool:
+				props[f.key] = strconv.FormatBool(field.ValueBool())
+			case types.Int64:
+				props[f.key] = strconv.FormatInt(field.ValueInt64(), 10)
+			}
+		}
+	}
+	return props, diags
+}
+
+// tabValue is the inverse of tabProperties.
+func tabValue(tab string, props map[string]string) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if tab == "advanced" {
+		values := map[string]attr.Value{}
+		for key, value := range props {
+			values[key] = types.StringValue(value)
+		}
+		return types.MapValue(types.StringType, values)
+	}
+
+	values := map[string]attr.Value{}
+	for _, f := range serverPropertyFields[tab] {
+		s, ok := props[f.key]
+		switch f.kind {
+		case serverPropertyBool:
+			values[f.name] = types.BoolNull()
+			if ok {
+				b, err := strconv.ParseBool(s)
+				if err != nil {
+					diags.AddError("Unexpected Server Property", fmt.Sprintf("The %s property %q is %q, which is not a boolean.", tab, f.key, s))
+					continue
+				}
+				values[f.name] = types.BoolValue(b)
+			}
+		case serverPropertyInt64:
+			values[f.name] = types.Int64Null()
+			if ok {
+				n, err := strconv.ParseInt(s, 10, 64)
+				if err != nil {
+					diags.AddError("Unexpected Server Property", fmt.Sprintf("The %s property %q is %q, which is not a number.", tab, f.key, s))
+					continue
+				}
+				values[f.name] = types.Int64Value(n)
+			}
+		default:
+			values[f.name] = types.StringNull()
+			if ok {
+				values[f.name] = types.StringValue(s)
+			}
+		}
+	}
+	if diags.HasError() {
+		return types.ObjectNull(serverPropertyTypes(tab)), diags
+	}
+	return types.ObjectValue(serverPropertyTypes(tab), values)
+}
+
+// isSensitiveServerProperty reports whether key is a sensitive property of tab.
+func isSensitiveServerProperty(tab, key string) bool {
+	for _, f := range serverPropertyFields[tab] {
+		if f.key == key {
+			return f.sensitive
+		}
+	}
+	return false
+}
+
+// writeServerProperties applies the planned property tabs of serverID. prior
+// holds the tabs from the previous state and is nil on create.
+func writeServerProperties(ctx context.Context, client *am.Client, serverID string, plan, prior *serverPropertiesModel, perServer bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, tab := range serverPropertyTabs {
+		planned := plan.tab(tab)
+		if planned.IsUnknown() {
+			continue
+		}
+		var old map[string]string
+		if prior != nil {
+			var d diag.Diagnostics
+			old, d = tabProperties(ctx, tab, prior.tab(tab))
+			diags.Append(d...)
+		}
+
+		// A tab removed from a server's configuration still resets the
+		// overrides of the prior state; the server defaults are left as
+		// they are.
+		if planned.IsNull() && (!perServer || old == nil) {
+			continue
+		}
+		desired, d := tabProperties(ctx, tab, planned)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+
+		current, err := client.GetServerProperties(ctx, serverID, tab)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read %s properties of server %s, got error: %s", tab, serverID, err))
+			return diags
+		}
+		props := current.Properties()
+
+		for key, value := range desired {
+			if err := current.Set(key, am.PropertyValue(value, props[key].Value)); err != nil {
+				diags.AddError("Invalid Server Property", fmt.Sprintf("Unable to set %s property %q: %s", tab, key, err))
+			}
+		}
+		if diags.HasError() {
+			return diags
+		}
+
+		var stale []string
+		for key := range old {
+			stale = append(stale, key)
+		}
+		if perServer && tab == "advanced" {
+			for key := range props {
+				stale = append(stale, key)
+			}
+		}
+		for _, key := range stale {
+			if _, ok := desired[key]; ok || !perServer && tab != "advanced" {
+				continue
+			}
+			if err := current.Inherit(key); err != nil {
+				diags.AddError("Invalid Server Property", fmt.Sprintf("Unable to reset %s property %q: %s", tab, key, err))
+			}
+		}
+
+		if _, err := client.UpdateServerProperties(ctx, serverID, tab, current); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update %s properties of server %s, got error: %s", tab, serverID, err))
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// readServerProperties refreshes the property tabs of serverID that are
+// present in tabs.
+func readServerProperties(ctx context.Context, client *am.Client, serverID string, tabs *serverPropertiesModel, perServer bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, tab := range serverPropertyTabs {
+		tracked, d := tabProperties(ctx, tab, tabs.tab(tab))
+		diags.Append(d...)
+		if tracked == nil {
+			continue
+		}
+
+		current, err := client.GetServerProperties(ctx, serverID, tab)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read %s properties of server %s, got error: %s", tab, serverID, err))
+			return diags
+		}
+		props := current.Properties()
+
+		keys := map[string]bool{}
+		for key := range tracked {
+			keys[key] = true
+		}
+		if perServer && tab == "advanced" {
+			for key := range props {
+				keys[key] = true
+			}
+		}
+
+		values := map[string]string{}
+		for key := range keys {
+			prior, isTracked := tracked[key]
+			if isTracked && isSensitiveServerProperty(tab, key) {
+				values[key] = prior
+				continue
+			}
+			prop, ok := props[key]
+			if !ok || perServer && prop.Inherited || bytes.Equal(bytes.TrimSpace(prop.Value), []byte("null")) {
+				continue
+			}
+			if isTracked && (prior == am.PropertyString(prop.Value) || jsonEqual(prior, prop.Value)) {
+				values[key] = prior
+				continue
+			}
+			values[key] = am.PropertyString(prop.Value)
+		}
+
+		value, d := tabValue(tab, values)
+		diags.Append(d...)
+		tabs.setTab(tab, value)
+	}
+
+	return diags
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTabPropertiesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	security, diags := types.ObjectValue(serverPropertyTypes("security"), map[string]attr.Value{
+		"encryptor":                 types.StringNull(),
+		"cookie_name":               types.StringValue("iPlanetDirectoryPro"),
+		"cookie_secure":             types.BoolValue(true),
+		"cookie_encode":             types.BoolNull(),
+		"client_ip_check_enabled":   types.BoolNull(),
+		"pll_max_content_length":    types.Int64Value(16384),
+		"ocsp_check_enabled":        types.BoolNull(),
+		"ocsp_responder_url":        types.StringNull(),
+		"deserialisation_whitelist": types.StringUnknown(),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	props, diags := tabProperties(ctx, "security", security)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	want := map[string]string{
+		"com.iplanet.am.cookie.name":                                   "iPlanetDirectoryPro",
+		"com.iplanet.am.cookie.secure":                                 "true",
+		"com.iplanet.services.comm.server.pllrequest.maxContentLength": "16384",
+	}
+	if len(props) != len(want) {
+		t.Fatalf("tabProperties = %v, want %v", props, want)
+	}
+	for key, value := range want {
+		if props[key] != value {
+			t.Errorf("tabProperties[%q] = %q, want %q", key, props[key], value)
+		}
+	}
+
+	props["com.iplanet.am.cookie.secure"] = "false"
+	got, diags := tabValue("security", props)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	fields := got.(types.Object).Attributes()
+	if !fields["cookie_secure"].Equal(types.BoolValue(false)) || !fields["pll_max_content_length"].Equal(types.Int64Value(16384)) ||
+		!fields["deserialisation_whitelist"].IsNull() {
+		t.Errorf("tabValue = %s", got)
+	}
+
+	if _, diags := tabValue("session", map[string]string{"com.iplanet.am.session.maxSessions": "lots"}); !diags.HasError() {
+		t.Error("tabValue accepted a number property that is not a number")
+	}
+}
+
+func TestReadServerProperties(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/global-config/servers/01/properties/general": `{"_id": "null/properties/general", "amconfig.header.debug": {` +
+			`"com.iplanet.services.debug.level": {"inherited": false, "value": "error"}, ` +
+			`"com.iplanet.services.debug.directory": {"inherited": true, "value": "%BASE_DIR%/var/debug"}}, ` +
+			`"amconfig.header.mailserver": {"com.iplanet.am.smtpport": {"inherited": false, "value": 2525}}}`,
+		"/global-config/servers/01/properties/advanced": `{"_id": "null/properties/advanced", ` +
+			`"com.sun.embedded.replicationport": "58989", "org.forgerock.openam.idm.attribute.names.lower.case": "true"}`,
+	})
+	ctx := context.Background()
+
+	general, _ := tabValue("general", map[string]string{"com.iplanet.services.debug.level": "message", "com.iplanet.am.smtpport": "25"})
+	advanced, _ := tabValue("advanced", map[string]string{"com.sun.embedded.replicationport": "58989"})
+	tabs := serverPropertiesModel{
+		General:  general.(types.Object),
+		Security: types.ObjectNull(serverPropertyTypes("security")),
+		Session:  types.ObjectNull(serverPropertyTypes("session")),
+		SDK:      types.ObjectNull(serverPropertyTypes("sdk")),
+		CTS:      types.ObjectNull(serverPropertyTypes("cts")),
+		Advanced: advanced.(types.Map),
+	}
+	if diags := readServerProperties(ctx, client.AM, "01", &tabs, true); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	fields := tabs.General.Attributes()
+	if !fields["debug_level"].Equal(types.StringValue("error")) || !fields["smtp_port"].Equal(types.Int64Value(2525)) ||
+		!fields["debug_directory"].IsNull() {
+		t.Errorf("general = %s, want the overridden debug level and SMTP port only", tabs.General)
+	}
+	var props map[string]string
+	tabs.Advanced.ElementsAs(ctx, &props, false)
+	if len(props) != 2 || props["org.forgerock.openam.idm.attribute.names.lower.case"] != "true" {
+		t.Errorf("advanced = %v, want every property set on the server", props)
+	}
+	if !tabs.Security.IsNull() {
+		t.Errorf("security = %s, want the untracked tab left null", tabs.Security)
+	}
+}
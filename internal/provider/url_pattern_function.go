@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &URLPatternMatchesFunction{}
+var _ function.Function = &URLPatternValidFunction{}
+
+func NewURLPatternMatchesFunction() function.Function {
+	return &URLPatternMatchesFunction{}
+}
+
+func NewURLPatternValidFunction() function.Function {
+	return &URLPatternValidFunction{}
+}
+
+// URLPatternMatchesFunction defines the url_pattern_matches function
+// implementation.
+type URLPatternMatchesFunction struct{}
+
+// URLPatternValidFunction defines the url_pattern_valid function
+// implementation.
+type URLPatternValidFunction struct{}
+
+// urlPatternRules summarizes am.URLPattern for the function documentation.
+const urlPatternRules = "`*` matches any characters including `/` but not the `?` that starts the query string; `-*-` matches within a single path segment and is not allowed in the query string. " +
+	"A pattern without `?` only matches URLs without a query string, use `*?*` to match both parts; query parameters may appear in any order. " +
+	"Scheme and host are case-insensitive, the default port may be omitted, and a `*` port also matches the default port."
+
+var urlPatternParameter = function.StringParameter{
+	Name:                "pattern",
+	MarkdownDescription: "An AM URL pattern, for example `https://app.example.com/*?*`.",
+}
+
+func (f *URLPatternMatchesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "url_pattern_matches"
+}
+
+func (f *URLPatternMatchesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Match a URL against an AM URL pattern",
+		MarkdownDescription: "Returns whether a URL matches an AM URL pattern, as used by policies, agent not-enforced lists and the validation service. " +
+			urlPatternRules + " Fails if the pattern is invalid.",
+		Parameters: []function.Parameter{
+			urlPatternParameter,
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The URL to match, for example `https://app.example.com/callback?state=1`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *URLPatternMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pattern, url string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pattern, &url))
+	if resp.Error != nil {
+		return
+	}
+
+	p, err := am.ParseURLPattern(pattern)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	matches, err := p.Match(url)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, matches))
+}
+
+func (f *URLPatternValidFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "url_pattern_valid"
+}
+
+func (f *URLPatternValidFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check an AM URL pattern",
+		MarkdownDescription: "Returns whether a string is a valid AM URL pattern: an absolute `http` or `https` URL with a host, optionally using wildcards, without whitespace, user information or a fragment. " +
+			"The provider validates the URL patterns of its resources with the same rules. " + urlPatternRules,
+		Parameters: []function.Parameter{urlPatternParameter},
+		Return:     function.BoolReturn{},
+	}
+}
+
+func (f *URLPatternValidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pattern string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pattern))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, validateURLPattern(pattern) == nil))
+}
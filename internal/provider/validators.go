@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.Set = urlPatternSetValidator{}
+var _ validator.Set = originSetValidator{}
+var _ validator.String = stringOneOfValidator{}
+var _ validator.Set = setOneOfValidator{}
+var _ validator.String = realmValidator{}
+
+// urlPatternSetValidator checks that every element of a set of strings is an
+// absolute http(s) URL, allowing AM's `*` and `-*-` wildcards.
+type urlPatternSetValidator struct{}
+
+func (v urlPatternSetValidator) Description(ctx context.Context) string {
+	return "each value must be an absolute http or https URL pattern"
+}
+
+func (v urlPatternSetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v urlPatternSetValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	for _, value := range stringSetElements(req.ConfigValue) {
+		if err := validateURLPattern(value); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL Pattern", fmt.Sprintf("%q: %s", value, err))
+		}
+	}
+}
+
+// originSetValidator checks that every element of a set of strings is a CORS
+// origin, i.e. a scheme, host and optional port without a path, or `*`, which
+// AM accepts as any origin.
+type originSetValidator struct{}
+
+func (v originSetValidator) Description(ctx context.Context) string {
+	return "each value must be an origin such as https://app.example.com:8443, or *"
+}
+
+func (v originSetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v originSetValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	for _, value := range stringSetElements(req.ConfigValue) {
+		if value == "*" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err == nil && (u.Scheme != "http" && u.Scheme != "https" || u.Host == "") {
+			err = fmt.Errorf("must use http or https and include a host")
+		}
+		if err == nil && (strings.TrimSuffix(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "") {
+			err = fmt.Errorf("must not contain a path, query or fragment")
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Origin", fmt.Sprintf("%q: %s", value, err))
+		}
+	}
+}
+
+// stringOneOfValidator checks that a string is one of a fixed set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid Value", fmt.Sprintf("%q: %s", req.ConfigValue.ValueString(), v.Description(ctx)))
+}
+
+// setOneOfValidator checks that every element of a set of strings is one of
+// a fixed set of values.
+type setOneOfValidator struct {
+	values []string
+}
+
+func (v setOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("each value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v setOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("each value must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v setOneOfValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	for _, value := range stringSetElements(req.ConfigValue) {
+		if !slices.Contains(v.values, value) {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Value", fmt.Sprintf("%q: %s", value, v.Description(ctx)))
+		}
+	}
+}
+
+// validateURLPattern reports whether pattern is a usable AM URL pattern,
+// using the same rules as url_pattern_valid.
+func validateURLPattern(pattern string) error {
+	_, err := am.ParseURLPattern(pattern)
+	return err
+}
+
+// stringSetElements returns the known string elements of a set, skipping
+// null and unknown values which cannot be validated yet.
+func stringSetElements(set types.Set) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var values []string
+	for _, element := range set.Elements() {
+		s, ok := element.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() {
+			continue
+		}
+		values = append(values, s.ValueString())
+	}
+	return values
+}
+
+// realmValidator checks that a string names a realm in one of the forms
+// am.ParseRealm accepts.
+type realmValidator struct{}
+
+func (v realmValidator) Description(ctx context.Context) string {
+	return "value must be a realm such as /, /alpha or /realms/root/realms/alpha"
+}
+
+func (v realmValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v realmValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := am.ParseRealm(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Realm", err.Error())
+	}
+}
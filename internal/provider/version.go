@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darkedges/terraform-provider-fram/internal/am"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// am65 is the oldest release the provider supports.
+var am65 = am.Version{Major: 6, Minor: 5}
+
+// am70 added the webhook service and the named CORS configurations.
+var am70 = am.Version{Major: 7}
+
+// MinimumAMVersion returns the oldest release the provider supports.
+func MinimumAMVersion() am.Version {
+	return am65
+}
+
+// versionRequirement declares the oldest AM release a resource works with.
+// SelfManaged is set for resources Identity Cloud does not offer, and
+// SelfManagedAttributes lists the attributes it rejects.
+type versionRequirement struct {
+	TypeName              string
+	Minimum               am.Version
+	SelfManaged           bool
+	SelfManagedAttributes []string
+}
+
+// checkVersion fails the plan when the server is older than the requirement,
+// or is an Identity Cloud tenant and the resource or a configured attribute
+// needs a self-managed AM. It is called from ModifyPlan so the check runs
+// against the configured provider before anything is sent to AM. A server
+// whose version cannot be read is not blocked; the API call itself will
+// report any problem.
+func checkVersion(ctx context.Context, client *FRAMClient, req resource.ModifyPlanRequest, requirement versionRequirement, diags *diag.Diagnostics) {
+	// Nothing to check when destroying or before the provider is configured.
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if client.AM.IsIdentityCloud() {
+		if requirement.SelfManaged {
+			diags.AddError(
+				"Unsupported on Identity Cloud",
+				fmt.Sprintf("%s requires a self-managed AM, but %s is an Identity Cloud tenant.", requirement.TypeName, client.AM.HostURL),
+			)
+			return
+		}
+		for _, name := range requirement.SelfManagedAttributes {
+			if !configured(ctx, req, name, diags) {
+				continue
+			}
+			diags.AddAttributeError(
+				path.Root(name),
+				"Unsupported Attribute",
+				fmt.Sprintf("%s.%s requires a self-managed AM, but %s is an Identity Cloud tenant. Remove the attribute.", requirement.TypeName, name, client.AM.HostURL),
+			)
+		}
+	}
+
+	version, err := client.AM.Version(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "unable to read the AM version, skipping version checks", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if !version.AtLeast(requirement.Minimum) {
+		diags.AddError(
+			"Unsupported AM Version",
+			fmt.Sprintf("%s requires AM %s or later, but the server reports %s.", requirement.TypeName, requirement.Minimum, version),
+		)
+	}
+}
+
+// configured reports whether the top-level attribute name is set in the
+// configuration of req.
+func configured(ctx context.Context, req resource.ModifyPlanRequest, name string, diags *diag.Diagnostics) bool {
+	var value attr.Value
+	diags.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+	return value != nil && !value.IsNull()
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/darkedges/terraform-provider-fram/internal/cli"
 	"github.com/darkedges/terraform-provider-fram/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -19,6 +21,12 @@ var (
 )
 
 func main() {
+	// Subcommands such as generate talk to AM directly; anything else starts
+	// the plugin server Terraform runs.
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")